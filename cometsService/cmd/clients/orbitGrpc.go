@@ -2,10 +2,13 @@ package clients
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
 	cometorbit "github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/grpc/cometorbit/proto"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/timescale"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -35,7 +38,8 @@ func (c *RealOrbitCalculationClient) CalculateOrbit(ctx context.Context, observa
 	grpcObservations := make([]*cometorbit.Observation, len(observations))
 	for i, obs := range observations {
 		grpcObservations[i] = &cometorbit.Observation{
-			TimeUtc:      obs.ObservedAt.Format("2006-01-02 15:04:05"),
+			TimeUtc:      obs.ObservedAt.UTC().Format("2006-01-02 15:04:05"),
+			JdTt:         timescale.JulianDate(obs.ObservedAt, timescale.TT),
 			RaDeg:        obs.RightAscension,
 			DecDeg:       obs.Declination,
 			IsHorizontal: obs.IsHorizontal, // предполагаем экваториальные координаты
@@ -59,15 +63,37 @@ func (c *RealOrbitCalculationClient) CalculateOrbit(ctx context.Context, observa
 		InclinationDeg:   	  response.InclinationDeg,
 		ArgumentOfPerihelion: response.ArgOfPeriapsisDeg,
 		TrueAnomalyDeg:       response.TrueAnomalyDeg,
+		Epoch:                parseEpochJD(response.EpochJd, response.EpochScale),
 	}, nil
 }
 
+// parseEpochJD переводит юлианскую дату эпохи из ответа сервиса во время UTC.
+// Пустая или некорректная строка означает, что эпоха неизвестна; пустая шкала —
+// UTC, как отвечали версии сервиса до появления поля epoch_scale.
+func parseEpochJD(epochJD, epochScale string) *time.Time {
+	jd, err := strconv.ParseFloat(strings.TrimSpace(epochJD), 64)
+	if err != nil {
+		return nil
+	}
+
+	scale := timescale.UTC
+	if strings.TrimSpace(epochScale) != "" {
+		if scale, err = timescale.ParseScale(epochScale); err != nil {
+			return nil
+		}
+	}
+
+	epoch := timescale.FromJulianDate(jd, scale)
+	return &epoch
+}
+
 // CalculateCloseApproach вычисляет ближайшее сближение с Землей
 func (c *RealOrbitCalculationClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
 	grpcObservations := make([]*cometorbit.Observation, len(observations))
 	for i, obs := range observations {
 		grpcObservations[i] = &cometorbit.Observation{
-			TimeUtc:      obs.ObservedAt.Format("2006-01-02 15:04:05"),
+			TimeUtc:      obs.ObservedAt.UTC().Format("2006-01-02 15:04:05"),
+			JdTt:         timescale.JulianDate(obs.ObservedAt, timescale.TT),
 			RaDeg:        obs.RightAscension,
 			DecDeg:       obs.Declination,
 			IsHorizontal: obs.IsHorizontal, // предполагаем экваториальные координаты
@@ -100,7 +126,8 @@ func (c *RealOrbitCalculationClient) GetTrajectory(ctx context.Context, observat
 	grpcObservations := make([]*cometorbit.Observation, len(observations))
 	for i, obs := range observations {
 		grpcObservations[i] = &cometorbit.Observation{
-			TimeUtc:      obs.ObservedAt.Format("2006-01-02 15:04:05"),
+			TimeUtc:      obs.ObservedAt.UTC().Format("2006-01-02 15:04:05"),
+			JdTt:         timescale.JulianDate(obs.ObservedAt, timescale.TT),
 			RaDeg:        obs.RightAscension,
 			DecDeg:       obs.Declination,
 			IsHorizontal: obs.IsHorizontal,
@@ -111,8 +138,8 @@ func (c *RealOrbitCalculationClient) GetTrajectory(ctx context.Context, observat
 		Observations: &cometorbit.ObservationsRequest{
 			Observations: grpcObservations,
 		},
-		StartTimeUtc: startTime.Format("2006-01-02 15:04:05"),
-		EndTimeUtc:   endTime.Format("2016-01-02 15:04:05"),
+		StartTimeUtc: startTime.UTC().Format("2006-01-02 15:04:05"),
+		EndTimeUtc:   endTime.UTC().Format("2006-01-02 15:04:05"),
 		NumPoints:    int32(numPoints),
 	}
 
@@ -0,0 +1,89 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/netguard"
+)
+
+const (
+	webhookTimeout      = 10 * time.Second
+	maxWebhookRedirects = 5
+)
+
+// WebhookNotifier отправляет уведомления о вспышках POST-запросом на адрес подписки
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier создает клиент уведомлений через webhook. Соединения с
+// внутренними адресами запрещены в самом dialer, поэтому их не открыть ни через
+// перенаправление, ни сменой DNS-записи после подписки; прокси не используется.
+func NewWebhookNotifier() *WebhookNotifier {
+	guard := netguard.FromEnv()
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: guard.Control}
+
+	return &WebhookNotifier{client: &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxWebhookRedirects {
+				return errors.New("too many redirects")
+			}
+			if !guard.HostAllowed(req.URL.Hostname()) {
+				return fmt.Errorf("%w: redirect to %s", netguard.ErrForbiddenAddress, req.URL.Hostname())
+			}
+			return nil
+		},
+	}}
+}
+
+// outburstPayload тело уведомления о вспышке
+type outburstPayload struct {
+	Event    string                `json:"event"` // Всегда "outburst"
+	Comet    outburstComet         `json:"comet"`
+	Outburst *domain.OutburstEvent `json:"outburst"`
+}
+
+type outburstComet struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Designation *string `json:"designation,omitempty"`
+}
+
+// NotifyOutburst отправляет событие; ответ с кодом вне 2xx считается ошибкой доставки
+func (n *WebhookNotifier) NotifyOutburst(ctx context.Context, subscription *domain.OutburstSubscription, comet *domain.Comet, event *domain.OutburstEvent) error {
+	body, err := json.Marshal(outburstPayload{
+		Event:    "outburst",
+		Comet:    outburstComet{ID: comet.ID, Name: comet.Name, Designation: comet.Designation},
+		Outburst: event,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", subscription.WebhookURL, resp.StatusCode)
+	}
+	return nil
+}
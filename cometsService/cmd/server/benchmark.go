@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/cmd/clients"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/benchmark"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/methods"
+)
+
+// BenchmarkOrbitsCommand прогоняет эталонные орбиты через выбранный клиент расчета орбит
+func BenchmarkOrbitsCommand(args []string) error {
+	fs := flag.NewFlagSet("benchmark-orbits", flag.ExitOnError)
+	client := fs.String("client", "grpc", "orbit backend: grpc, mock or a local method (gauss, gauss+lsq, laplace, herget, vaisala)")
+	addr := fs.String("addr", os.Getenv("ORBIT_SERVICE_ADDR"), "orbit service address for grpc client")
+	noise := fs.Float64("noise", 1, "gaussian noise per coordinate, arcsec")
+	trials := fs.Int("trials", 5, "noise realizations per reference orbit")
+	seed := fs.Int64("seed", 1, "random seed")
+	_ = fs.Parse(args)
+
+	var orbitClient domain.IOrbitCalculationClient
+	switch *client {
+	case "grpc":
+		if *addr == "" {
+			return fmt.Errorf("benchmark-orbits: -addr or ORBIT_SERVICE_ADDR is required for grpc client")
+		}
+		realClient, err := clients.NewRealOrbitCalculationClient(*addr)
+		if err != nil {
+			return fmt.Errorf("benchmark-orbits: %w", err)
+		}
+		defer realClient.Close()
+		orbitClient = realClient
+	case "mock":
+		orbitClient = NewMockOrbitCalculationClient()
+	default:
+		local := methods.NewRegistry()
+		methods.RegisterLocal(local)
+		localClient, ok := local.Client(*client)
+		if !ok {
+			return fmt.Errorf("benchmark-orbits: unknown client %q", *client)
+		}
+		orbitClient = localClient
+	}
+
+	report, err := benchmark.Run(context.Background(), orbitClient, benchmark.DefaultCases(), benchmark.Config{
+		NoiseArcsec: *noise,
+		Trials:      *trials,
+		Seed:        *seed,
+	})
+	if err != nil {
+		return fmt.Errorf("benchmark-orbits: %w", err)
+	}
+	return report.Write(os.Stdout)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/repository"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/catalog"
+)
+
+// ImportCatalog загружает справочный каталог комет из локального файла
+func ImportCatalog(path, format string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open catalog file: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := catalog.Parse(format, file)
+	if err != nil {
+		return fmt.Errorf("failed to parse catalog: %w", err)
+	}
+
+	repo := repository.NewCometsRepository()
+	if repo == nil {
+		return fmt.Errorf("failed to connect postgres")
+	}
+
+	importedAt := time.Now()
+	comets := make([]*domain.CatalogComet, len(entries))
+	for i, e := range entries {
+		comets[i] = &domain.CatalogComet{
+			Designation:          e.Designation,
+			Name:                 e.Name,
+			PerihelionDistance:   e.PerihelionDistance,
+			Eccentricity:         e.Eccentricity,
+			InclinationDeg:       e.InclinationDeg,
+			RaanDeg:              e.RaanDeg,
+			ArgumentOfPerihelion: e.ArgumentOfPerihelion,
+			PerihelionTime:       e.PerihelionTime,
+			Epoch:                e.Epoch,
+			AbsoluteMagnitude:    e.AbsoluteMagnitude,
+			SlopeParameter:       e.SlopeParameter,
+			Source:               strings.ToLower(format),
+			Reference:            e.Reference,
+			ImportedAt:           importedAt,
+		}
+	}
+
+	if err := repo.UpsertCatalogComets(context.Background(), comets); err != nil {
+		return fmt.Errorf("failed to store catalog: %w", err)
+	}
+
+	log.Printf("Imported %d catalog comets from %s", len(comets), path)
+	return nil
+}
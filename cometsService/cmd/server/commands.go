@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+)
+
+// runCommand выполняет служебную подкоманду сервера
+func runCommand(name string, args []string) error {
+	switch name {
+	case "import-catalog":
+		fs := flag.NewFlagSet(name, flag.ExitOnError)
+		file := fs.String("file", "", "path to CometEls.txt or SBDB JSON dump")
+		format := fs.String("format", "mpc", "catalog format: mpc or jpl")
+		_ = fs.Parse(args)
+
+		if *file == "" {
+			return fmt.Errorf("import-catalog: -file is required")
+		}
+		return ImportCatalog(*file, *format)
+	case "generate-observations":
+		return GenerateObservationsCommand(args)
+	case "benchmark-orbits":
+		return BenchmarkOrbitsCommand(args)
+	case "migrate":
+		return Migrate()
+	default:
+		log.Println("Available commands: import-catalog, generate-observations, benchmark-orbits, migrate")
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/cmd/clients"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/handlers"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/methods"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/repository"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/service"
 	"github.com/gin-gonic/gin"
@@ -16,6 +18,14 @@ import (
 func main() {
 	_ = godotenv.Load()
 
+	// Служебные подкоманды (импорт каталога и т.п.) выполняются без запуска сервера
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Получение переменных окружения
 	appPort := os.Getenv("APP_PORT")
 	runMigrations := os.Getenv("RUN_MIGRATIONS")
@@ -37,6 +47,9 @@ func main() {
 	var orbitCalcClient domain.IOrbitCalculationClient
 	var authClient domain.IAuthClient
 
+	// Реестр методов определения орбиты; порядок регистрации задает порядок перебора
+	orbitMethods := methods.NewRegistry()
+
 	// В зависимости от окружения используем реальные или mock клиенты
 	useRealClients := os.Getenv("USE_REAL_CLIENTS")
 
@@ -57,16 +70,27 @@ func main() {
 		// orbitCalcClient = realOrbitClient
 		orbitCalcClient = realOrbitClient
 
+		orbitMethods.Register("grpc", realOrbitClient)
 	} else {
 		authClient = NewMockAuthClient()
+		// Mock нужен только для сближений и траекторий: в реестр методов он не попадает,
+		// чтобы клиенты не могли сохранить выдуманную орбиту
+		orbitCalcClient = NewMockOrbitCalculationClient()
 	}
+	methods.RegisterLocal(orbitMethods)
 
 	// Инициализация сервиса
-	cometsService := service.NewCometsService(cometRepo, orbitCalcClient)
+	cometsService := service.NewCometsService(cometRepo, orbitCalcClient, orbitMethods, clients.NewWebhookNotifier())
 	if cometsService == nil {
 		log.Fatal("Failed to initialize comets service (likely MinIO connection issue)")
 	}
 
+	// Фоновый пересчет орбит, устаревших после изменения наблюдений
+	cometsService.StartOrbitScheduler(context.Background(), service.OrbitSchedulerConfigFromEnv())
+
+	// Окончательное удаление комет, пролежавших в корзине дольше срока хранения
+	cometsService.StartTrashRetention(context.Background())
+
 	// Настройка роутера
 	router := gin.Default()
 
@@ -99,7 +123,8 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Total-Count, X-Next-Cursor")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -7,7 +7,11 @@ import (
 	"time"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/service"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/catalog"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/database"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/synthetic"
 	"gorm.io/gorm"
 )
 
@@ -23,10 +27,26 @@ func Migrate() error {
 	err = db.AutoMigrate(
 		&domain.Comet{},
 		&domain.Observation{},
+		&domain.CatalogComet{},
+		&domain.AuditEntry{},
+		&domain.OutburstEvent{},
+		&domain.OutburstSubscription{},
+		&domain.CalendarFeed{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to auto migrate: %w", err)
 	}
+	// Начальная загрузка справочного каталога комет
+	if catalogFile := os.Getenv("CATALOG_FILE"); catalogFile != "" {
+		format := os.Getenv("CATALOG_FORMAT")
+		if format == "" {
+			format = catalog.FormatMPC
+		}
+		if err := ImportCatalog(catalogFile, format); err != nil {
+			log.Printf("Warning: Failed to import catalog: %v", err)
+		}
+	}
+
 	seedTestData := os.Getenv("SEED_TEST_DATA")
 	if seedTestData == "true" {
 		if err := SeedTestData(db); err != nil {
@@ -70,8 +90,8 @@ func SeedTestData(db *gorm.DB) error {
 			Name:                 "Комета Энке",
 			SemiMajorAxis:        2.21,
 			Eccentricity:         0.847,
-			RaanDeg:              11.78,
-			InclinationDeg:    	334.57,
+			RaanDeg:              334.57,
+			InclinationDeg:    	11.78,
 			ArgumentOfPerihelion: 186.54,
 			TrueAnomalyDeg:       11.78,
 		},
@@ -85,43 +105,45 @@ func SeedTestData(db *gorm.DB) error {
 		}
 	}
 
-	// Тестовые наблюдения
-	observations := []domain.Observation{
-		{
-			UserID:         1,
-			CometID:        &comets[0].ID,
-			RightAscension: 45.67,
-			Declination:    23.45,
-			ObservedAt:     parseTime("2023-12-01T20:00:00Z"),
-		},
-		{
-			UserID:         1,
-			CometID:        &comets[0].ID,
-			RightAscension: 46.12,
-			Declination:    23.78,
-			ObservedAt:     parseTime("2023-12-02T21:00:00Z"),
-		},
-		{
-			UserID:         1,
-			CometID:        &comets[0].ID,
-			RightAscension: 46.89,
-			Declination:    24.12,
-			ObservedAt:     parseTime("2023-12-03T22:00:00Z"),
-		},
-		{
-			UserID:         2,
-			CometID:        &comets[2].ID,
-			RightAscension: 120.45,
-			Declination:    -15.67,
-			ObservedAt:     parseTime("2023-10-20T19:30:00Z"),
-		},
-		{
-			UserID:         2,
-			CometID:        &comets[2].ID,
-			RightAscension: 121.23,
-			Declination:    -15.89,
-			ObservedAt:     parseTime("2023-10-21T20:15:00Z"),
-		},
+	// Тестовые наблюдения генерируются по орбитам комет: ночь за ночью из Москвы с шумом 1"
+	site := orbit.Site{Latitude: 55.75, Longitude: 37.62, Altitude: 150}
+	epoch := parseTime("2023-12-01T00:00:00Z")
+	seeds := []struct {
+		comet  *domain.Comet
+		start  string
+		nights int
+	}{
+		{&comets[0], "2023-12-01T20:00:00Z", 6},
+		{&comets[2], "2023-10-20T19:30:00Z", 6},
+	}
+
+	var observations []domain.Observation
+	for i, seed := range seeds {
+		generated, err := service.GenerateSyntheticObservations(&domain.OrbitalElements{
+			SemiMajorAxis:        seed.comet.SemiMajorAxis,
+			Eccentricity:         seed.comet.Eccentricity,
+			RaanDeg:              seed.comet.RaanDeg,
+			InclinationDeg:       seed.comet.InclinationDeg,
+			ArgumentOfPerihelion: seed.comet.ArgumentOfPerihelion,
+			TrueAnomalyDeg:       seed.comet.TrueAnomalyDeg,
+			Epoch:                &epoch,
+		}, synthetic.Config{
+			Site:        site,
+			Start:       parseTime(seed.start),
+			End:         parseTime(seed.start).Add(time.Duration(seed.nights-1) * 24 * time.Hour),
+			Cadence:     24 * time.Hour,
+			NoiseArcsec: 1,
+			Seed:        int64(i + 1),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate observations for %s: %w", seed.comet.Name, err)
+		}
+
+		for _, obs := range generated {
+			obs.UserID = seed.comet.UserID
+			obs.CometID = &seed.comet.ID
+			observations = append(observations, *obs)
+		}
 	}
 
 	// Создаем наблюдения
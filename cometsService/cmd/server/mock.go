@@ -20,6 +20,7 @@ func (m *MockOrbitCalculationClient) CalculateOrbit(ctx context.Context, observa
 		return nil, domain.ErrNotEnoughObservations
 	}
 
+	epoch := observations[len(observations)-1].ObservedAt
 	return &domain.OrbitalElements{
 		SemiMajorAxis:        17.8,
 		Eccentricity:         0.967,
@@ -27,6 +28,7 @@ func (m *MockOrbitCalculationClient) CalculateOrbit(ctx context.Context, observa
 		InclinationDeg:    58.42,
 		ArgumentOfPerihelion: 111.33,
 		TrueAnomalyDeg:       162.3,
+		Epoch:                &epoch,
 	}, nil
 }
 
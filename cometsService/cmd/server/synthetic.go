@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/service"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/synthetic"
+)
+
+// GenerateObservationsCommand печатает синтетические наблюдения в формате CreateObservationRequest
+func GenerateObservationsCommand(args []string) error {
+	fs := flag.NewFlagSet("generate-observations", flag.ExitOnError)
+	a := fs.Float64("a", 0, "semi-major axis, AU (negative for hyperbola)")
+	e := fs.Float64("e", 0, "eccentricity")
+	i := fs.Float64("i", 0, "inclination, deg")
+	node := fs.Float64("node", 0, "longitude of ascending node, deg")
+	peri := fs.Float64("peri", 0, "argument of perihelion, deg")
+	nu := fs.Float64("nu", 0, "true anomaly at epoch, deg")
+	epoch := fs.String("epoch", "", "orbit epoch, RFC3339")
+	lat := fs.Float64("lat", 0, "observer latitude, deg")
+	lon := fs.Float64("lon", 0, "observer east longitude, deg")
+	alt := fs.Float64("alt", 0, "observer altitude, m")
+	start := fs.String("start", "", "first observation time, RFC3339")
+	end := fs.String("end", "", "last observation time, RFC3339")
+	cadence := fs.Duration("cadence", 24*time.Hour, "interval between observations")
+	noise := fs.Float64("noise", 1, "gaussian noise per coordinate, arcsec")
+	seed := fs.Int64("seed", 1, "random seed")
+	visibleOnly := fs.Bool("visible-only", false, "keep only observations made at night above the horizon")
+	_ = fs.Parse(args)
+
+	epochTime, err := time.Parse(time.RFC3339, *epoch)
+	if err != nil {
+		return fmt.Errorf("generate-observations: invalid -epoch: %w", err)
+	}
+	startTime, err := time.Parse(time.RFC3339, *start)
+	if err != nil {
+		return fmt.Errorf("generate-observations: invalid -start: %w", err)
+	}
+	endTime, err := time.Parse(time.RFC3339, *end)
+	if err != nil {
+		return fmt.Errorf("generate-observations: invalid -end: %w", err)
+	}
+
+	observations, err := service.GenerateSyntheticObservations(&domain.OrbitalElements{
+		SemiMajorAxis:        *a,
+		Eccentricity:         *e,
+		RaanDeg:              *node,
+		InclinationDeg:       *i,
+		ArgumentOfPerihelion: *peri,
+		TrueAnomalyDeg:       *nu,
+		Epoch:                &epochTime,
+	}, synthetic.Config{
+		Site:        orbit.Site{Latitude: *lat, Longitude: *lon, Altitude: *alt},
+		Start:       startTime,
+		End:         endTime,
+		Cadence:     *cadence,
+		NoiseArcsec: *noise,
+		Seed:        *seed,
+		VisibleOnly: *visibleOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("generate-observations: %w", err)
+	}
+
+	requests := make([]domain.CreateObservationRequest, len(observations))
+	for i, obs := range observations {
+		requests[i] = domain.CreateObservationRequest{
+			RightAscension: domain.Degrees(obs.RightAscension),
+			Declination:    domain.Degrees(obs.Declination),
+			ObservedAt:     obs.ObservedAt.Format(time.RFC3339),
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(requests)
+}
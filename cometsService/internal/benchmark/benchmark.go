@@ -0,0 +1,238 @@
+// Package benchmark оценивает точность реализаций domain.IOrbitCalculationClient:
+// наблюдения генерируются по известным орбитам с шумом, а найденные элементы
+// сравниваются с эталонными.
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/service"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/synthetic"
+)
+
+// Case эталонная орбита и схема наблюдений
+type Case struct {
+	Name     string
+	Elements domain.OrbitalElements // Epoch обязателен
+	Site     orbit.Site
+	Start    time.Time
+	Span     time.Duration
+	Cadence  time.Duration
+}
+
+// Config параметры прогона
+type Config struct {
+	NoiseArcsec float64
+	Trials      int // Число реализаций шума на каждую орбиту
+	Seed        int64
+}
+
+// ElementErrors абсолютные ошибки элементов (углы в градусах)
+type ElementErrors struct {
+	SemiMajorAxis        float64 // Относительная ошибка |Δa/a|
+	Eccentricity         float64
+	InclinationDeg       float64
+	RaanDeg              float64
+	ArgumentOfPerihelion float64
+	TrueAnomalyDeg       float64
+}
+
+// CaseResult сводка по одной эталонной орбите
+type CaseResult struct {
+	Name         string
+	Observations int
+	Runs         int
+	Failures     int // Ошибки расчета и физически недопустимые решения
+	MeanErrors   ElementErrors
+	MaxErrors    ElementErrors
+	MeanDuration time.Duration
+	MaxDuration  time.Duration
+	LastError    string
+}
+
+// Report результат прогона всех орбит
+type Report struct {
+	NoiseArcsec float64
+	Trials      int
+	Cases       []*CaseResult
+}
+
+// DefaultCases набор эталонных орбит разных типов
+func DefaultCases() []Case {
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	site := orbit.Site{Latitude: 55.75, Longitude: 37.62, Altitude: 150}
+	elements := func(a, e, i, node, peri, nu float64) domain.OrbitalElements {
+		return domain.OrbitalElements{
+			SemiMajorAxis:        a,
+			Eccentricity:         e,
+			InclinationDeg:       i,
+			RaanDeg:              node,
+			ArgumentOfPerihelion: peri,
+			TrueAnomalyDeg:       nu,
+			Epoch:                &epoch,
+		}
+	}
+
+	return []Case{
+		{"encke-type", elements(2.215, 0.847, 11.78, 334.57, 186.54, 300), site, epoch, 30 * 24 * time.Hour, 24 * time.Hour},
+		{"jupiter-family", elements(3.46, 0.63, 7.04, 50.1, 12.8, 330), site, epoch, 45 * 24 * time.Hour, 24 * time.Hour},
+		{"halley-type", elements(17.8, 0.967, 162.26, 58.42, 111.33, 320), site, epoch, 60 * 24 * time.Hour, 24 * time.Hour},
+		{"long-period", elements(270, 0.9989, 128.94, 61.01, 37.28, 330), site, epoch, 20 * 24 * time.Hour, 12 * time.Hour},
+		{"hyperbolic", elements(-5.0, 1.2, 44.0, 120.0, 80.0, 340), site, epoch, 60 * 24 * time.Hour, 48 * time.Hour},
+	}
+}
+
+// Run прогоняет все орбиты через клиент расчета орбит
+func Run(ctx context.Context, client domain.IOrbitCalculationClient, cases []Case, cfg Config) (*Report, error) {
+	if cfg.Trials <= 0 {
+		cfg.Trials = 1
+	}
+
+	report := &Report{NoiseArcsec: cfg.NoiseArcsec, Trials: cfg.Trials}
+	for ci, c := range cases {
+		result := &CaseResult{Name: c.Name}
+		var totalDuration time.Duration
+		successes := 0
+
+		for trial := 0; trial < cfg.Trials; trial++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			observations, err := service.GenerateSyntheticObservations(&c.Elements, synthetic.Config{
+				Site:        c.Site,
+				Start:       c.Start,
+				End:         c.Start.Add(c.Span),
+				Cadence:     c.Cadence,
+				NoiseArcsec: cfg.NoiseArcsec,
+				Seed:        cfg.Seed + int64(ci*cfg.Trials+trial),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("case %s: %w", c.Name, err)
+			}
+			result.Observations = len(observations)
+			result.Runs++
+
+			started := time.Now()
+			solution, err := client.CalculateOrbit(ctx, observations)
+			duration := time.Since(started)
+
+			totalDuration += duration
+			if duration > result.MaxDuration {
+				result.MaxDuration = duration
+			}
+
+			if err == nil {
+				err = solution.Validate()
+			}
+			if err != nil {
+				result.Failures++
+				result.LastError = err.Error()
+				continue
+			}
+
+			errs := compare(&c.Elements, solution)
+			accumulate(&result.MeanErrors, &result.MaxErrors, errs)
+			successes++
+		}
+
+		if successes > 0 {
+			scale(&result.MeanErrors, 1/float64(successes))
+		}
+		result.MeanDuration = totalDuration / time.Duration(result.Runs)
+		report.Cases = append(report.Cases, result)
+	}
+
+	return report, nil
+}
+
+// compare вычисляет ошибки решения; истинная аномалия сравнивается на эпоху решения
+func compare(reference, solution *domain.OrbitalElements) ElementErrors {
+	referenceNu := reference.TrueAnomalyDeg
+	if solution.Epoch != nil && !solution.Epoch.Equal(*reference.Epoch) {
+		el, err := orbit.FromKeplerian(
+			reference.SemiMajorAxis,
+			reference.Eccentricity,
+			reference.InclinationDeg,
+			reference.RaanDeg,
+			reference.ArgumentOfPerihelion,
+			reference.TrueAnomalyDeg,
+			orbit.JulianDate(*reference.Epoch),
+		)
+		if err == nil {
+			referenceNu, _ = el.TrueAnomaly(orbit.JulianDate(*solution.Epoch))
+		}
+	}
+
+	return ElementErrors{
+		SemiMajorAxis:        math.Abs((solution.SemiMajorAxis - reference.SemiMajorAxis) / reference.SemiMajorAxis),
+		Eccentricity:         math.Abs(solution.Eccentricity - reference.Eccentricity),
+		InclinationDeg:       math.Abs(solution.InclinationDeg - reference.InclinationDeg),
+		RaanDeg:              angleDiff(solution.RaanDeg, reference.RaanDeg),
+		ArgumentOfPerihelion: angleDiff(solution.ArgumentOfPerihelion, reference.ArgumentOfPerihelion),
+		TrueAnomalyDeg:       angleDiff(solution.TrueAnomalyDeg, referenceNu),
+	}
+}
+
+func angleDiff(a, b float64) float64 {
+	return math.Abs(math.Remainder(a-b, 360))
+}
+
+func accumulate(sum, max *ElementErrors, e ElementErrors) {
+	pairs := []struct {
+		sum, max *float64
+		v        float64
+	}{
+		{&sum.SemiMajorAxis, &max.SemiMajorAxis, e.SemiMajorAxis},
+		{&sum.Eccentricity, &max.Eccentricity, e.Eccentricity},
+		{&sum.InclinationDeg, &max.InclinationDeg, e.InclinationDeg},
+		{&sum.RaanDeg, &max.RaanDeg, e.RaanDeg},
+		{&sum.ArgumentOfPerihelion, &max.ArgumentOfPerihelion, e.ArgumentOfPerihelion},
+		{&sum.TrueAnomalyDeg, &max.TrueAnomalyDeg, e.TrueAnomalyDeg},
+	}
+	for _, p := range pairs {
+		*p.sum += p.v
+		*p.max = math.Max(*p.max, p.v)
+	}
+}
+
+func scale(e *ElementErrors, k float64) {
+	e.SemiMajorAxis *= k
+	e.Eccentricity *= k
+	e.InclinationDeg *= k
+	e.RaanDeg *= k
+	e.ArgumentOfPerihelion *= k
+	e.TrueAnomalyDeg *= k
+}
+
+// Write печатает отчет в виде таблицы
+func (r *Report) Write(w io.Writer) error {
+	fmt.Fprintf(w, "noise: %.2f arcsec, trials per orbit: %d\n", r.NoiseArcsec, r.Trials)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "case\tobs\truns\tfail\tda/a\tde\tdi\tdnode\tdperi\tdnu\tmean time\tmax time\t")
+	for _, c := range r.Cases {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2e\t%.2e\t%.4f\t%.4f\t%.4f\t%.4f\t%s\t%s\t\n",
+			c.Name, c.Observations, c.Runs, c.Failures,
+			c.MeanErrors.SemiMajorAxis, c.MeanErrors.Eccentricity, c.MeanErrors.InclinationDeg,
+			c.MeanErrors.RaanDeg, c.MeanErrors.ArgumentOfPerihelion, c.MeanErrors.TrueAnomalyDeg,
+			c.MeanDuration.Round(time.Microsecond), c.MaxDuration.Round(time.Microsecond))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, c := range r.Cases {
+		if c.LastError != "" {
+			fmt.Fprintf(w, "%s: last error: %s\n", c.Name, c.LastError)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/cmd/clients"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/methods"
+)
+
+// fixedClient возвращает заранее заданное решение
+type fixedClient struct {
+	elements *domain.OrbitalElements
+	err      error
+}
+
+func (c *fixedClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	result := *c.elements
+	return &result, nil
+}
+
+func (c *fixedClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fixedClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRunExactSolution(t *testing.T) {
+	c := DefaultCases()[0]
+	client := &fixedClient{elements: &c.Elements}
+
+	report, err := Run(context.Background(), client, []Case{c}, Config{NoiseArcsec: 1, Trials: 3, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := report.Cases[0]
+	if result.Runs != 3 || result.Failures != 0 {
+		t.Fatalf("runs=%d failures=%d, want 3 and 0", result.Runs, result.Failures)
+	}
+	if result.Observations == 0 {
+		t.Fatal("no observations generated")
+	}
+	if result.MaxErrors != (ElementErrors{}) {
+		t.Fatalf("exact solution reported errors: %+v", result.MaxErrors)
+	}
+}
+
+func TestRunComparesAtSolutionEpoch(t *testing.T) {
+	c := DefaultCases()[1]
+	solution := c.Elements
+	later := c.Elements.Epoch.Add(10 * 24 * time.Hour)
+	solution.Epoch = &later
+
+	report, err := Run(context.Background(), &fixedClient{elements: &solution}, []Case{c}, Config{Trials: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Аномалия не пересчитана на новую эпоху, ошибка должна быть заметной
+	if got := report.Cases[0].MaxErrors.TrueAnomalyDeg; got < 0.1 {
+		t.Fatalf("true anomaly error %.4f, want a shift from propagation", got)
+	}
+}
+
+func TestRunCountsFailures(t *testing.T) {
+	invalid := DefaultCases()[0].Elements
+	invalid.Eccentricity = -1
+
+	backends := map[string]domain.IOrbitCalculationClient{
+		"error":   &fixedClient{err: domain.ErrNotEnoughObservations},
+		"invalid": &fixedClient{elements: &invalid},
+	}
+	for name, client := range backends {
+		report, err := Run(context.Background(), client, DefaultCases()[:2], Config{Trials: 2})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, result := range report.Cases {
+			if result.Failures != result.Runs || result.LastError == "" {
+				t.Fatalf("%s: case %s failures=%d runs=%d", name, result.Name, result.Failures, result.Runs)
+			}
+		}
+	}
+}
+
+// BenchmarkGauss замеряет локальный метод Гаусса
+func BenchmarkGauss(b *testing.B) {
+	client := methods.NewGaussClient()
+	for _, c := range DefaultCases() {
+		b.Run(c.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				report, err := Run(context.Background(), client, []Case{c}, Config{NoiseArcsec: 1, Seed: int64(i)})
+				if err != nil {
+					b.Fatal(err)
+				}
+				result := report.Cases[0]
+				b.ReportMetric(float64(result.Failures), "failures/op")
+				b.ReportMetric(result.MeanErrors.Eccentricity, "de/op")
+				b.ReportMetric(result.MeanErrors.InclinationDeg, "di-deg/op")
+			}
+		})
+	}
+}
+
+// BenchmarkOrbitService замеряет реальный сервис расчета орбит, если задан ORBIT_SERVICE_ADDR
+func BenchmarkOrbitService(b *testing.B) {
+	addr := os.Getenv("ORBIT_SERVICE_ADDR")
+	if addr == "" {
+		b.Skip("ORBIT_SERVICE_ADDR is not set")
+	}
+
+	client, err := clients.NewRealOrbitCalculationClient(addr)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, c := range DefaultCases() {
+		b.Run(c.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				report, err := Run(context.Background(), client, []Case{c}, Config{NoiseArcsec: 1, Seed: int64(i)})
+				if err != nil {
+					b.Fatal(err)
+				}
+				result := report.Cases[0]
+				b.ReportMetric(float64(result.Failures), "failures/op")
+				b.ReportMetric(result.MeanErrors.Eccentricity, "de/op")
+				b.ReportMetric(result.MeanErrors.InclinationDeg, "di-deg/op")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Coordinate значение координаты в запросе в том виде, в каком его прислал
+// клиент: число или строка. Разбор и проверка диапазона выполняются в сервисе,
+// поэтому нулевое значение отличается от отсутствующего только флагом Set.
+type Coordinate struct {
+	Raw string
+	Set bool
+}
+
+// Degrees координата, заданная числом в градусах
+func Degrees(v float64) Coordinate {
+	return Coordinate{Raw: strconv.FormatFloat(v, 'f', -1, 64), Set: true}
+}
+
+func (c *Coordinate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*c = Coordinate{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*c = Coordinate{Raw: s, Set: true}
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("coordinate must be a number or a string: %w", err)
+	}
+	*c = Coordinate{Raw: n.String(), Set: true}
+	return nil
+}
+
+// MarshalJSON записывает числовое значение числом, остальные — строкой
+func (c Coordinate) MarshalJSON() ([]byte, error) {
+	if !c.Set {
+		return []byte("null"), nil
+	}
+	if _, err := strconv.ParseFloat(c.Raw, 64); err == nil {
+		return []byte(c.Raw), nil
+	}
+	return json.Marshal(c.Raw)
+}
@@ -12,20 +12,61 @@ var (
 	ErrUnauthorized          = errors.New("unauthorized access")
 	ErrInvalidInput          = errors.New("invalid input data")
 	ErrOrbitNotCalculated    = errors.New("orbit not calculated for this comet")
+	ErrUnsupportedArc        = errors.New("observation arc is not supported by orbit method")
+	ErrConflict              = errors.New("resource already exists")
 )
 
 type ICometsRepository interface {
 	CreateComets(ctx context.Context, comet *Comet) error
 	GetCometsByID(ctx context.Context, id int) (*Comet, error)
 	GetCometsByUserID(ctx context.Context, userID int) ([]*Comet, error)
+	GetCometByDesignation(ctx context.Context, userID int, designation string) (*Comet, error)
+	CountCometsByPhotoURL(ctx context.Context, photoURL string, excludeID int) (int64, error)
+	GetStaleComets(ctx context.Context, minObservations, limit int, excludeIDs []int) ([]*Comet, error)
+	GetPendingDynamicsComets(ctx context.Context, limit int) ([]*Comet, error)
+	UpdateCometDynamics(ctx context.Context, comet *Comet) error
+	UpdateCometCalculation(ctx context.Context, comet *Comet, readCalculatedAt time.Time) error
+	ListComets(ctx context.Context, filter CometFilter, page PageRequest) (*CometPage, error)
 	UpdateComets(ctx context.Context, comet *Comet) error
 	DeleteComets(ctx context.Context, id int, userID int) error
 
+	GetDeletedComets(ctx context.Context, userID int) ([]*TrashedComet, error)
+	GetDeletedComet(ctx context.Context, id int, userID int) (*Comet, error)
+	GetExpiredComets(ctx context.Context, deletedBefore time.Time, limit int) ([]*Comet, error)
+	RestoreComet(ctx context.Context, comet *Comet) error
+	PurgeComet(ctx context.Context, id int, userID int) error
+
 	CreateObservation(ctx context.Context, observation *Observation) error
+	CreateCometWithObservations(ctx context.Context, comet *Comet, observations []*Observation) error
 	GetObservationByID(ctx context.Context, id int) (*Observation, error)
 	GetUserObservationsByCometID(ctx context.Context, cometID int, userID int) ([]*Observation, error)
+	ListObservations(ctx context.Context, filter ObservationFilter, page PageRequest) (*ObservationPage, error)
 	UpdateObservation(ctx context.Context, observation *Observation) error
 	DeleteObservation(ctx context.Context, id int, userID int) error
+
+	CreateScenario(ctx context.Context, sourceID int, scenario *Comet) error
+	GetScenariosByCometID(ctx context.Context, cometID int, userID int) ([]*Comet, error)
+	DeleteScenario(ctx context.Context, id int, userID int) error
+
+	MergeComets(ctx context.Context, targetID, sourceID int, userID int, audit *AuditEntry) error
+	SplitComet(ctx context.Context, sourceID int, userID int, observationIDs []int, newComet *Comet, audit *AuditEntry) error
+	AssignObservations(ctx context.Context, cometID int, userID int, observationIDs []int, audit *AuditEntry) error
+
+	ReplaceOutbursts(ctx context.Context, cometID int, events []*OutburstEvent) ([]*OutburstEvent, error)
+	GetOutburstsByCometID(ctx context.Context, cometID int, userID int) ([]*OutburstEvent, error)
+	MarkOutburstNotified(ctx context.Context, id int, at time.Time) error
+	UpsertOutburstSubscription(ctx context.Context, subscription *OutburstSubscription) error
+	GetOutburstSubscriptions(ctx context.Context, cometID int) ([]*OutburstSubscription, error)
+	DeleteOutburstSubscription(ctx context.Context, cometID int, userID int) error
+
+	UpsertCalendarFeed(ctx context.Context, feed *CalendarFeed) error
+	GetCalendarFeedByToken(ctx context.Context, token string) (*CalendarFeed, error)
+	DeleteCalendarFeed(ctx context.Context, userID int) error
+
+	UpsertCatalogComets(ctx context.Context, comets []*CatalogComet) error
+	GetCatalogCometByID(ctx context.Context, id int) (*CatalogComet, error)
+	SearchCatalogComets(ctx context.Context, query string, limit int) ([]*CatalogComet, error)
+	GetAllCatalogComets(ctx context.Context) ([]*CatalogComet, error)
 }
 
 // ICometsService интерфейс для сервиса комет и наблюдений
@@ -33,24 +74,69 @@ type ICometsService interface {
 	// Observation methods
 	CreateObservation(ctx context.Context, userID int, req *CreateObservationRequest) (*Observation, error)
 	GetObservation(ctx context.Context, id int) (*Observation, error)
-	GetUserObservations(ctx context.Context, userID int) ([]*Observation, error)
+	GetUserObservations(ctx context.Context, userID int, req *ListObservationsRequest) (*ObservationPage, error)
 	GetUserObservationsByCometID(ctx context.Context, cometID int, userID int) ([]*Observation, error)
 	UpdateObservation(ctx context.Context, userID, id int, req *UpdateObservationRequest) error
 	DeleteObservation(ctx context.Context, id int, userID int) error
+	GetUnassignedObservations(ctx context.Context, userID int, req *ListUnassignedObservationsRequest) (*ObservationPage, error)
+	SuggestComets(ctx context.Context, userID int, req *SuggestCometsRequest) ([]*ObservationSuggestion, *ObservationPage, error)
+	AssignObservations(ctx context.Context, userID int, req *AssignObservationsRequest) (*Comet, error)
+
+	GenerateObservations(ctx context.Context, userID int, req *GenerateObservationsRequest) (*GeneratedObservationsResponse, error)
 
 	// Comet methods
-	CreateComet(ctx context.Context, userID int, name string, fileData []byte, fileName string) (*Comet, error)
+	CreateComet(ctx context.Context, userID int, name, designation string, fileData []byte, fileName string) (*Comet, error)
 	GetComet(ctx context.Context, id int) (*Comet, error)
-	GetUserComets(ctx context.Context, userID int) ([]*Comet, error)
+	LookupComet(ctx context.Context, userID int, designation string) (*Comet, error)
+	GetUserComets(ctx context.Context, userID int, req *ListCometsRequest) (*CometPage, error)
+	ClusterUserComets(ctx context.Context, userID int, req *ClusterCometsRequest) (*CometClusterReport, error)
+	UpdateComet(ctx context.Context, userID, id int, req *UpdateCometRequest) (*Comet, error)
 	DeleteComet(ctx context.Context, id int, userID int) error
+	MergeComets(ctx context.Context, userID, targetID int, req *MergeCometRequest) (*Comet, error)
+	SplitComet(ctx context.Context, userID, sourceID int, req *SplitCometRequest) (*Comet, error)
+	CreateScenario(ctx context.Context, userID, cometID int, req *CreateScenarioRequest) (*Comet, error)
+	GetScenarios(ctx context.Context, userID, cometID int) ([]*Comet, error)
+	DiscardScenario(ctx context.Context, userID, cometID, scenarioID int) error
+
+	// Trash methods
+	GetTrash(ctx context.Context, userID int) ([]*TrashedComet, error)
+	RestoreComet(ctx context.Context, userID, cometID int) (*Comet, error)
+	PurgeComet(ctx context.Context, userID, cometID int) error
 
 	// Calculation methods
-	CalculateOrbit(ctx context.Context, userID, cometID int) (*CometOrbitResponse, error)
+	CalculateOrbit(ctx context.Context, userID, cometID int, method string) (*CometOrbitResponse, error)
+	GetOrbitMethods() []string
+	RefineOrbit(ctx context.Context, userID, cometID int, req *RefineOrbitRequest) (*OrbitRefinementResponse, error)
 	CalculateCloseApproach(ctx context.Context, userID, cometID int) (*CometDistanceResponse, error)
+	CalculateDynamics(ctx context.Context, userID, cometID int) (*CometOrbitResponse, error)
 	GetTrajectory(ctx context.Context, userID, cometID int, startTime, endTime time.Time, numPoints int) (*Trajectory, error)
+	SandboxOrbit(ctx context.Context, userID int, req *SandboxOrbitRequest) (*SandboxOrbitResponse, error)
+	IdentifyOrbit(ctx context.Context, userID, cometID int, req *IdentifyOrbitRequest) ([]*OrbitIdentification, error)
+	GetMeteorShowers(ctx context.Context, userID, cometID int, req *MeteorShowerRequest) (*MeteorShowerReport, error)
+	GetLightCurve(ctx context.Context, userID, cometID int, req *LightCurveRequest) (*LightCurveResponse, error)
+	GetAfRho(ctx context.Context, userID, cometID int, req *AfRhoRequest) (*AfRhoResponse, error)
+	GetOutbursts(ctx context.Context, userID, cometID int) ([]*OutburstEvent, error)
+	DetectOutbursts(ctx context.Context, userID, cometID int, req *DetectOutburstsRequest) ([]*OutburstEvent, error)
+	SubscribeOutbursts(ctx context.Context, userID, cometID int, req *OutburstSubscriptionRequest) (*OutburstSubscription, error)
+	UnsubscribeOutbursts(ctx context.Context, userID, cometID int) error
+
+	// Event calendar methods
+	GetCometEvents(ctx context.Context, userID, cometID int, req *EventsRequest) ([]*CometEvent, error)
+	GetUserEvents(ctx context.Context, userID int, req *EventsRequest) ([]*CometEvent, error)
+	CreateCalendarFeed(ctx context.Context, userID int) (*CalendarFeed, error)
+	DeleteCalendarFeed(ctx context.Context, userID int) error
+	GetCalendarFeedEvents(ctx context.Context, token string, req *EventsRequest) ([]*CometEvent, error)
+
+	// Background recalculation
+	GetOrbitSchedulerStatus() *OrbitSchedulerStatus
 
 	// File upload methods
 	UploadCometPhoto(ctx context.Context, userID, cometID int, fileData []byte, fileName string) (*Comet, error)
+
+	// Catalog methods
+	SearchCatalog(ctx context.Context, query string, limit int) ([]*CatalogComet, error)
+	GetCatalogComet(ctx context.Context, id int) (*CatalogComet, error)
+	CreateCometFromCatalog(ctx context.Context, userID, catalogID int, name string) (*Comet, error)
 }
 
 // AuthClient интерфейс для сервиса авторизации
@@ -65,9 +151,21 @@ type IOrbitCalculationClient interface {
 	GetTrajectory(ctx context.Context, observations []*Observation, startTime, endTime time.Time, numPoints int) (*Trajectory, error)
 }
 
+// IOrbitArcChecker реализуется методами определения орбиты, которые применимы не к любой дуге.
+// CheckArc возвращает ErrUnsupportedArc, если метод не справится с наблюдениями.
+type IOrbitArcChecker interface {
+	CheckArc(observations []*Observation) error
+}
+
+// IOutburstNotifier доставляет подписчикам уведомления о найденных вспышках
+type IOutburstNotifier interface {
+	NotifyOutburst(ctx context.Context, subscription *OutburstSubscription, comet *Comet, event *OutburstEvent) error
+}
+
 // FileStorageClient интерфейс для сервиса хранения файлов
 type IFileStorageClient interface {
 	UploadPhoto(ctx context.Context, userID int, fileData []byte, fileName string) (string, error)
 	DeletePhoto(ctx context.Context, photoURL string) error
 	GetPhotoURL(ctx context.Context, photoURL string) (string, error)
+	IsUserPhoto(photoURL string, userID int) bool
 }
@@ -13,12 +13,45 @@ type Observation struct {
 	ObservedAt     time.Time `json:"observed_at"`
 	Comet          *Comet    `json:"comet,omitempty" gorm:"foreignKey:CometID"`
 	IsHorizontal   bool      `json:"is_horizontal"`
+	// Система отсчета, в которой наблюдатель передал координаты: ICRF, B1950 или APPARENT.
+	// RightAscension и Declination всегда хранятся приведенными к ICRF, исходные
+	// значения сохраняются в Reported*, если потребовался пересчет.
+	Frame                  string   `json:"frame"`
+	ReportedRightAscension *float64 `json:"reported_right_ascension,omitempty"`
+	ReportedDeclination    *float64 `json:"reported_declination,omitempty"`
+	// Фотометрия: полная (m1) и ядерная (m2) звездные величины, полоса и апертура инструмента
+	TotalMagnitude   *float64 `json:"total_magnitude,omitempty"`
+	NuclearMagnitude *float64 `json:"nuclear_magnitude,omitempty"`
+	MagnitudeBand    string   `json:"magnitude_band,omitempty"` // V, R, B, I, G или visual
+	ApertureCm       *float64 `json:"aperture_cm,omitempty"`    // Апертура инструмента, см
+	// Диафрагменная фотометрия для Afρ: радиус диафрагмы, в которой измерена
+	// total_magnitude, и фильтр (B, V, Rc, Ic, g', r', i')
+	ApertureRadiusArcsec *float64 `json:"aperture_radius_arcsec,omitempty"`
+	Filter               string   `json:"filter,omitempty"`
+	// Помечается вместе с кометой при ее удалении в корзину и снимается при восстановлении
+	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// Источники орбитальных элементов кометы
+const (
+	OrbitSourceComputed = "computed"
+	OrbitSourceManual   = "manual"
+	OrbitSourceCatalog  = "catalog"
+)
+
+// Динамические классы долгопериодических комет по исходной барицентрической 1/a
+const (
+	DynamicalClassNew        = "dynamically_new" // 0 <= 1/a < 1e-4 1/а.е.: первый приход из облака Оорта
+	DynamicalClassOld        = "dynamically_old" // 1/a >= 1e-4 1/а.е.
+	DynamicalClassHyperbolic = "hyperbolic"      // 1/a < 0: исходная орбита незамкнута
+)
+
 type Comet struct {
 	ID                   int        `json:"id" gorm:"primaryKey"`
-	UserID               int        `json:"user_id"`
+	UserID               int        `json:"user_id" gorm:"uniqueIndex:idx_comets_user_designation,priority:1,where:deleted_at IS NULL AND scenario_of IS NULL"`
 	Name                 string     `json:"name"`
+	// Нормализованное обозначение МАС ("C/2020 F3", "73P-B"), уникальное среди комет пользователя
+	Designation          *string    `json:"designation,omitempty" gorm:"uniqueIndex:idx_comets_user_designation,priority:2"`
 	PhotoURL             string     `json:"photo_url"`
 	SemiMajorAxis        float64    `json:"semi_major_axis"`
 	Eccentricity         float64    `json:"eccentricity"`
@@ -27,13 +60,61 @@ type Comet struct {
 	ArgumentOfPerihelion float64    `json:"argument_of_perihelion"`
 	OrbitActual          bool       `json:"orbit_actual"`
 	TrueAnomalyDeg       float64    `json:"true_anomaly_deg"`
+	OrbitEpoch           *time.Time `json:"orbit_epoch"`  // Эпоха оскуляции как момент UTC
+	OrbitSource          string     `json:"orbit_source"` // computed, manual или catalog
+	OrbitMethod          string     `json:"orbit_method,omitempty"` // Метод, которым вычислена орбита
 	MinApproachDate      *time.Time `json:"min_approach_date"`
 	MinApproachDistance  *float64   `json:"min_approach_distance"`
 	CloseActual          bool       `json:"close_actual"`
 	CalculatedAt         time.Time  `json:"calculated_at"`
+	// Барицентрические 1/a исходной и будущей орбит (1/а.е.), только для долгопериодических комет
+	OriginalInverseA     *float64   `json:"original_inverse_a,omitempty"`
+	FutureInverseA       *float64   `json:"future_inverse_a,omitempty"`
+	DynamicalClass       string     `json:"dynamical_class,omitempty"`
+	// Орбита сменилась, 1/a ждут фонового расчета или POST /calculations/:comet_id/dynamics
+	DynamicsPending      bool       `json:"dynamics_pending,omitempty" gorm:"index"`
+	IsScenario           bool       `json:"is_scenario"`
+	ScenarioOf           *int       `json:"scenario_of,omitempty" gorm:"index"` // Комета, от которой отделен сценарий
 	DeletedAt            *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// CatalogComet запись справочного каталога известных комет (только для чтения).
+// Элементы перигелийные, эклиптика и равноденствие J2000.
+type CatalogComet struct {
+	ID                   int        `json:"id" gorm:"primaryKey"`
+	Designation          string     `json:"designation" gorm:"uniqueIndex"`
+	Name                 string     `json:"name" gorm:"index"`
+	PerihelionDistance   float64    `json:"perihelion_distance"`
+	Eccentricity         float64    `json:"eccentricity"`
+	InclinationDeg       float64    `json:"inclination_deg"`
+	RaanDeg              float64    `json:"raan_deg"`
+	ArgumentOfPerihelion float64    `json:"argument_of_perihelion"`
+	PerihelionTime       time.Time  `json:"perihelion_time"` // Моменты UTC, переведенные из шкалы источника (TT/TDB)
+	Epoch                *time.Time `json:"epoch"`
+	AbsoluteMagnitude    *float64   `json:"absolute_magnitude"`
+	SlopeParameter       *float64   `json:"slope_parameter"`
+	Source               string     `json:"source"` // mpc или jpl
+	Reference            string     `json:"reference"`
+	ImportedAt           time.Time  `json:"imported_at"`
+}
+
+// Действия, фиксируемые в журнале аудита
+const (
+	AuditActionMerge  = "merge"
+	AuditActionSplit  = "split"
+	AuditActionAssign = "assign"
+)
+
+// AuditEntry запись журнала изменений данных пользователя
+type AuditEntry struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	UserID    int       `json:"user_id" gorm:"index"`
+	CometID   int       `json:"comet_id" gorm:"index"`
+	Action    string    `json:"action"`
+	Details   string    `json:"details"` // JSON с параметрами операции
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type CalculationRequest struct {
 	ID           int    `json:"id" gorm:"primaryKey"`
 	UserID       int    `json:"user_id"`
@@ -49,6 +130,7 @@ type OrbitalElements struct {
 	InclinationDeg   		 float64
 	ArgumentOfPerihelion float64
 	TrueAnomalyDeg       float64
+	Epoch                *time.Time // Эпоха, к которой относится истинная аномалия
 }
 
 type CloseApproach struct {
@@ -68,3 +150,37 @@ type Trajectory struct {
 	CometTrajectory []TrajectoryPoint `json:"comet_trajectory"`
 	EarthTrajectory []TrajectoryPoint `json:"earth_trajectory"`
 }
+
+// OutburstEvent возможная вспышка: оценка блеска ярче закона m = M1 + 5 lg Δ + 2.5 K1 lg r
+// больше чем на порог. Хранится отдельно для каждого наблюдения кометы.
+type OutburstEvent struct {
+	ID                 int        `json:"id" gorm:"primaryKey"`
+	UserID             int        `json:"user_id" gorm:"index"`
+	CometID            int        `json:"comet_id" gorm:"index"`
+	ObservationID      int        `json:"observation_id" gorm:"uniqueIndex"`
+	ObservedAt         time.Time  `json:"observed_at"`
+	Magnitude          float64    `json:"magnitude"`
+	PredictedMagnitude float64    `json:"predicted_magnitude"`
+	Brightening        float64    `json:"brightening"` // Насколько комета ярче закона, зв. вел.
+	Threshold          float64    `json:"threshold"`
+	DetectedAt         time.Time  `json:"detected_at"`
+	NotifiedAt         *time.Time `json:"notified_at,omitempty"`
+}
+
+// OutburstSubscription подписка пользователя на уведомления о вспышках кометы
+type OutburstSubscription struct {
+	ID         int       `json:"id" gorm:"primaryKey"`
+	UserID     int       `json:"user_id" gorm:"uniqueIndex:idx_outburst_subscription"`
+	CometID    int       `json:"comet_id" gorm:"uniqueIndex:idx_outburst_subscription"`
+	WebhookURL string    `json:"webhook_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CalendarFeed секретная ссылка на календарь событий пользователя в формате iCalendar.
+// Календарные приложения не передают токен авторизации, поэтому доступ дает сам Token.
+type CalendarFeed struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	UserID    int       `json:"user_id" gorm:"uniqueIndex"`
+	Token     string    `json:"token" gorm:"uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Размер страницы списков по умолчанию и наибольший допустимый
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// ShortPeriodMaxSemiMajorAxis большая полуось орбиты с периодом 200 лет, а.е.:
+// граница между коротко- и долгопериодическими кометами
+const ShortPeriodMaxSemiMajorAxis = 34.2
+
+// Классы орбит для фильтра списка комет
+const (
+	OrbitClassShortPeriod = "short_period"
+	OrbitClassLongPeriod  = "long_period"
+	OrbitClassHyperbolic  = "hyperbolic"
+	OrbitClassNone        = "none" // Орбита еще не определялась
+)
+
+// Поля сортировки списков; первое — сортировка по умолчанию
+var (
+	CometSortFields       = []string{"id", "name", "calculated_at", "eccentricity", "semi_major_axis"}
+	ObservationSortFields = []string{"observed_at", "id"}
+)
+
+// PageCursor позиция в списке: значение поля сортировки и ID последней отданной записи
+type PageCursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// Encode непрозрачное строковое представление курсора для параметра cursor
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor разбирает курсор, полученный от клиента
+func DecodePageCursor(s string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	var c PageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	return &c, nil
+}
+
+// PageRequest проверенные параметры страницы для репозитория
+type PageRequest struct {
+	Limit  int
+	Sort   string // Одно из *SortFields
+	Desc   bool
+	Cursor *PageCursor // nil — первая страница
+}
+
+// CometFilter условия выборки комет пользователя (кроме удаленных и сценариев)
+type CometFilter struct {
+	UserID      int
+	Query       string // Подстрока названия или обозначения
+	OrbitActual *bool
+	OrbitClass  string
+}
+
+// ObservationFilter условия выборки наблюдений пользователя: наблюдения его комет
+// либо, если задано Unassigned, наблюдения без кометы
+type ObservationFilter struct {
+	UserID     int
+	CometID    *int
+	Unassigned bool
+	From       *time.Time
+	To         *time.Time
+}
+
+// CometPage страница списка комет
+type CometPage struct {
+	Comets     []*Comet
+	Total      int64  // Всего записей, подходящих под фильтр
+	NextCursor string // Пусто на последней странице
+}
+
+// ObservationPage страница списка наблюдений
+type ObservationPage struct {
+	Observations []*Observation
+	Total        int64
+	NextCursor   string
+}
@@ -1,34 +1,250 @@
 package domain
 
+// Координаты принимаются числом или строкой: "12 34 56.7", "12h34m56.7s",
+// "-05°30'15\"", десятичные часы или градусы. Единица задается полями
+// ra_unit/dec_unit (deg, hours, sexagesimal); по умолчанию одиночное число —
+// градусы, запись из нескольких полей — часы (RA) или градусы (Dec) с минутами и секундами.
 type CreateObservationRequest struct {
-	CometID        *int    `json:"comet_id"`
-	RightAscension float64 `json:"right_ascension" binding:"required"`
-	Declination    float64 `json:"declination" binding:"required"`
-	ObservedAt     string  `json:"observed_at" binding:"required"`
-	IsHorizontal   bool    `json:"is_horizontal"`
+	CometID        *int       `json:"comet_id"`
+	RightAscension Coordinate `json:"right_ascension"`
+	RAUnit         string     `json:"ra_unit"`
+	Declination    Coordinate `json:"declination"`
+	DecUnit        string     `json:"dec_unit"`
+	ObservedAt     string     `json:"observed_at" binding:"required"`
+	IsHorizontal   bool       `json:"is_horizontal"`
+	Frame          string     `json:"frame"` // ICRF (J2000), B1950 или APPARENT; по умолчанию ICRF
+	Photometry
 }
 
+// UpdateObservationRequest полная замена координат наблюдения. right_ascension и
+// declination задаются в системе frame, по умолчанию ICRF — в той же, в которой их
+// возвращает GET, поэтому отредактированный ответ GET можно отправить как есть.
+// Чтобы снова передать координаты в B1950 или APPARENT, frame указывается явно.
 type UpdateObservationRequest struct {
-	RightAscension float64 `json:"right_ascension" binding:"required"`
-	Declination    float64 `json:"declination" binding:"required"`
-	ObservedAt     string  `json:"observed_at" binding:"required"`
+	RightAscension Coordinate `json:"right_ascension"`
+	RAUnit         string     `json:"ra_unit"`
+	Declination    Coordinate `json:"declination"`
+	DecUnit        string     `json:"dec_unit"`
+	ObservedAt     string     `json:"observed_at" binding:"required"`
+	Frame          string     `json:"frame"` // ICRF (по умолчанию), B1950 или APPARENT
+	Photometry
+}
+
+// Photometry необязательные оценки блеска, общие для создания и изменения наблюдения
+type Photometry struct {
+	TotalMagnitude   *float64 `json:"total_magnitude"`
+	NuclearMagnitude *float64 `json:"nuclear_magnitude"`
+	MagnitudeBand    string   `json:"magnitude_band"`
+	ApertureCm       *float64 `json:"aperture_cm" binding:"omitempty,gt=0"`
+	// Радиус фотометрической диафрагмы и фильтр; вместе с total_magnitude дают Afρ
+	ApertureRadiusArcsec *float64 `json:"aperture_radius_arcsec" binding:"omitempty,gt=0"`
+	Filter               string   `json:"filter"`
 }
 
 type CreateCometRequest struct {
-	Name     string `form:"name" binding:"required"`
-	PhotoURL string `json:"photo_url"`
+	Name        string `form:"name" binding:"required"`
+	Designation string `form:"designation"` // Обозначение МАС; по умолчанию извлекается из названия
+	PhotoURL    string `json:"photo_url"`
 }
 
-// чисто для бека
+// UpdateCometRequest частичное обновление кометы: меняются только переданные поля.
+// Передача любого орбитального элемента переводит орбиту в режим manual.
+// Новое фото загружается через POST /comets/:comet_id/photo; в photo_url можно передать
+// только пустую строку (снять фото) или ссылку на фото, загруженное этим пользователем.
 type UpdateCometRequest struct {
-	Name                 string  `json:"name"`
-	PhotoURL             string  `json:"photo_url"`
+	Name                 *string  `json:"name"`
+	Designation          *string  `json:"designation"` // Пустая строка снимает обозначение
+	PhotoURL             *string  `json:"photo_url"`
+	SemiMajorAxis        *float64 `json:"semi_major_axis"`
+	Eccentricity         *float64 `json:"eccentricity"`
+	RaanDeg              *float64 `json:"raan_deg"`
+	InclinationDeg       *float64 `json:"inclination_deg"`
+	ArgumentOfPerihelion *float64 `json:"argument_of_perihelion"`
+	TrueAnomalyDeg       *float64 `json:"true_anomaly_deg"`
+	OrbitEpoch           *string  `json:"orbit_epoch"` // "2006-01-02T15:04:05Z"
+}
+
+// HasOrbitalElements сообщает, задан ли в запросе хотя бы один орбитальный элемент
+func (r *UpdateCometRequest) HasOrbitalElements() bool {
+	return r.SemiMajorAxis != nil || r.Eccentricity != nil || r.RaanDeg != nil ||
+		r.InclinationDeg != nil || r.ArgumentOfPerihelion != nil ||
+		r.TrueAnomalyDeg != nil || r.OrbitEpoch != nil
+}
+
+// PageParams параметры постраничной выдачи списков. cursor берется из заголовка
+// X-Next-Cursor предыдущего ответа; sort и order при этом должны совпадать.
+type PageParams struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// ListCometsRequest фильтры списка комет
+type ListCometsRequest struct {
+	PageParams
+	Query       string `form:"q"` // Подстрока названия или обозначения
+	OrbitActual *bool  `form:"orbit_actual"`
+	OrbitClass  string `form:"orbit_class" binding:"omitempty,oneof=short_period long_period hyperbolic none"`
+}
+
+// ListObservationsRequest фильтры списка наблюдений
+type ListObservationsRequest struct {
+	PageParams
+	CometID *int   `form:"comet_id"`
+	From    string `form:"from"` // RFC3339, по моменту наблюдения
+	To      string `form:"to"`   // RFC3339
+}
+
+// ListUnassignedObservationsRequest фильтры списка наблюдений без кометы
+type ListUnassignedObservationsRequest struct {
+	PageParams
+	From string `form:"from"` // RFC3339
+	To   string `form:"to"`   // RFC3339
+}
+
+// SuggestCometsRequest подбор комет для страницы наблюдений без кометы
+type SuggestCometsRequest struct {
+	ListUnassignedObservationsRequest
+	MaxSeparation *float64 `form:"max_separation" binding:"omitempty,gt=0,max=180"` // Наибольшее отклонение от предвычисленного положения, градусы
+	Candidates    int      `form:"candidates" binding:"omitempty,min=1,max=10"`     // Кандидатов на наблюдение, по умолчанию 3
+}
+
+// AssignObservationsRequest привязка наблюдений без кометы к комете пользователя
+type AssignObservationsRequest struct {
+	CometID        int   `json:"comet_id" binding:"required"`
+	ObservationIDs []int `json:"observation_ids" binding:"required,min=1"`
+}
+
+// LightCurveRequest период прогноза блеска; по умолчанию полгода от текущего момента
+type LightCurveRequest struct {
+	From     string  `form:"from"` // RFC3339
+	To       string  `form:"to"`   // RFC3339
+	StepDays float64 `form:"step_days" binding:"omitempty,gt=0"`
+}
+
+// AfRhoRequest фазовый коэффициент для приведения Afρ к нулевому фазовому углу
+type AfRhoRequest struct {
+	PhaseCoefficient *float64 `form:"phase_coefficient" binding:"omitempty,gte=0"` // зв. вел./градус
+}
+
+// EventsRequest период календаря событий; по умолчанию год от текущего момента
+type EventsRequest struct {
+	From string `form:"from"` // RFC3339
+	To   string `form:"to"`   // RFC3339
+}
+
+// DetectOutburstsRequest поиск вспышек; порог по умолчанию задается OUTBURST_THRESHOLD_MAG
+type DetectOutburstsRequest struct {
+	Threshold *float64 `form:"threshold" binding:"omitempty,gt=0"` // Превышение блеска над законом, зв. вел.
+}
+
+// OutburstSubscriptionRequest подписка на уведомления о вспышках кометы
+type OutburstSubscriptionRequest struct {
+	WebhookURL string `json:"webhook_url" binding:"required"` // На адрес отправляется POST с событием в JSON
+}
+
+// LookupCometRequest поиск кометы пользователя по обозначению в любой форме:
+// "C/2020 F3", "c2020f3", "CK20F030", "73P-B", "0073Pb"
+type LookupCometRequest struct {
+	Designation string `form:"designation" binding:"required"`
+}
+
+type SearchCatalogRequest struct {
+	Query string `form:"q"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// CreateCometFromCatalogRequest создание кометы пользователя по записи каталога
+type CreateCometFromCatalogRequest struct {
+	Name string `json:"name"` // По умолчанию берется из каталога
+}
+
+// CalculateOrbitRequest выбор метода определения орбиты (query или тело запроса)
+type CalculateOrbitRequest struct {
+	Method string `form:"method" json:"method"` // По умолчанию первый подходящий метод
+}
+
+// RefineOrbitRequest параметры дифференциального уточнения орбиты
+type RefineOrbitRequest struct {
+	MaxIterations int `form:"max_iterations" binding:"omitempty,min=1,max=200"`
+	// Force разрешает заменить введенную вручную или взятую из каталога орбиту вычисленной
+	Force bool `form:"force"`
+}
+
+// IdentifyOrbitRequest параметры отождествления орбиты с каталогом
+type IdentifyOrbitRequest struct {
+	Criterion string   `form:"criterion"` // sh (по умолчанию), d или dh
+	Threshold *float64 `form:"threshold" binding:"omitempty,gt=0"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// MeteorShowerRequest параметры поиска метеорных потоков, связанных с кометой
+type MeteorShowerRequest struct {
+	Criterion string   `form:"criterion"` // sh (по умолчанию), d или dh
+	Threshold *float64 `form:"threshold" binding:"omitempty,gt=0"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	MaxMiss   *float64 `form:"max_miss" binding:"omitempty,gt=0,max=1"` // Допустимое удаление узла от орбиты Земли, а.е.
+}
+
+// ClusterCometsRequest параметры поиска семейств и фрагментов среди комет пользователя
+type ClusterCometsRequest struct {
+	Criterion string   `form:"criterion"` // sh (по умолчанию), d или dh
+	Threshold *float64 `form:"threshold" binding:"omitempty,gt=0"`
+}
+
+// MergeCometRequest перенос всех наблюдений кометы-источника в целевую комету
+type MergeCometRequest struct {
+	SourceCometID int `json:"source_comet_id" binding:"required"`
+}
+
+// SplitCometRequest перенос выбранных наблюдений в новую комету
+type SplitCometRequest struct {
+	ObservationIDs []int  `json:"observation_ids" binding:"required,min=1"`
+	Name           string `json:"name"` // По умолчанию "<имя исходной кометы> (split)"
+}
+
+// SandboxOrbitRequest расчет орбиты по произвольным наблюдениям без сохранения в базу
+type SandboxOrbitRequest struct {
+	Observations []CreateObservationRequest `json:"observations" binding:"required,min=1,max=500,dive"`
+	Trajectory   *SandboxTrajectoryRequest  `json:"trajectory"` // Необязательный расчет траектории
+	Method       string                     `json:"method"`     // Метод определения орбиты, по умолчанию первый подходящий
+}
+
+type SandboxTrajectoryRequest struct {
+	StartTime string `json:"start_time" binding:"required"` // "2006-01-02T15:04:05Z"
+	EndTime   string `json:"end_time" binding:"required"`   // "2006-01-02T15:04:05Z"
+	NumPoints int    `json:"num_points" binding:"required,min=10,max=1000"`
+}
+
+// CreateScenarioRequest копирование кометы с наблюдениями в сценарий для экспериментов
+type CreateScenarioRequest struct {
+	Name string `json:"name"` // По умолчанию "<имя кометы> (scenario)"
+}
+
+// GenerateObservationsRequest генерация синтетических наблюдений по известной орбите
+type GenerateObservationsRequest struct {
 	SemiMajorAxis        float64 `json:"semi_major_axis"`
 	Eccentricity         float64 `json:"eccentricity"`
 	RaanDeg              float64 `json:"raan_deg"`
-	AscendingNodeLong    float64 `json:"ascending_node_long"`
+	InclinationDeg       float64 `json:"inclination_deg"`
 	ArgumentOfPerihelion float64 `json:"argument_of_perihelion"`
-	TrueAnomalyDeg       string  `json:"true_anomaly_deg"`
+	TrueAnomalyDeg       float64 `json:"true_anomaly_deg"`
+	OrbitEpoch           string  `json:"orbit_epoch" binding:"required"` // "2006-01-02T15:04:05Z"
+
+	Latitude  float64 `json:"latitude" binding:"min=-90,max=90"`    // Пункт наблюдения, градусы
+	Longitude float64 `json:"longitude" binding:"min=-180,max=360"` // Восточная долгота, градусы
+	Altitude  float64 `json:"altitude"`                             // Высота, м
+
+	StartTime    string  `json:"start_time" binding:"required"` // "2006-01-02T15:04:05Z"
+	EndTime      string  `json:"end_time" binding:"required"`   // "2006-01-02T15:04:05Z"
+	CadenceHours float64 `json:"cadence_hours" binding:"required,gt=0"`
+	NoiseArcsec  float64 `json:"noise_arcsec" binding:"min=0"`
+	Seed         *int64  `json:"seed"`
+	VisibleOnly  bool    `json:"visible_only"`
+
+	// Если задано, наблюдения сохраняются в новую комету с этим названием
+	CometName string `json:"comet_name"`
 }
 
 type GetTrajectoryRequest struct {
@@ -14,10 +14,11 @@ type ObservationResponse struct {
 }
 
 type CometCreatedResponse struct {
-	ID       int    `json:"id"`
-	UserID   int    `json:"user_id"`
-	Name     string `json:"name"`
-	PhotoURL string `json:"photo_url"`
+	ID          int     `json:"id"`
+	UserID      int     `json:"user_id"`
+	Name        string  `json:"name"`
+	Designation *string `json:"designation,omitempty"`
+	PhotoURL    string  `json:"photo_url"`
 }
 
 type CometResponse struct {
@@ -28,10 +29,13 @@ type CometResponse struct {
 	SemiMajorAxis        *float64   `json:"semi_major_axis"`
 	Eccentricity         *float64   `json:"eccentricity"`
 	RaanDeg              *float64   `json:"raan_deg"`
-	InclinationDeg   	 *float64   `json:"inclination_deg"`
+	InclinationDeg       *float64   `json:"inclination_deg"`
 	ArgumentOfPerihelion *float64   `json:"argument_of_perihelion"`
 	OrbitActual          bool       `json:"orbit_actual"`
 	TrueAnomalyDeg       *float64   `json:"true_anomaly_deg"`
+	OrbitEpoch           *time.Time `json:"orbit_epoch"`
+	OrbitSource          string     `json:"orbit_source"`
+	OrbitMethod          string     `json:"orbit_method,omitempty"`
 	MinApproachDate      *time.Time `json:"min_approach_date"`
 	MinApproachDistance  *float64   `json:"min_approach_distance"`
 	CloseActual          bool       `json:"close_actual"`
@@ -39,14 +43,128 @@ type CometResponse struct {
 }
 
 type CometOrbitResponse struct {
-	ID                   int      `json:"id"`
-	SemiMajorAxis        *float64 `json:"semi_major_axis"`
-	Eccentricity         *float64 `json:"eccentricity"`
-	RaanDeg              *float64 `json:"raan_deg"`
-	InclinationDeg   	 *float64 `json:"inclination_deg"`
-	ArgumentOfPerihelion *float64 `json:"argument_of_perihelion"`
-	TrueAnomalyDeg       *float64 `json:"true_anomaly_deg"`
-	OrbitActual          bool     `json:"orbit_actual"`
+	ID                   int        `json:"id"`
+	SemiMajorAxis        *float64   `json:"semi_major_axis"`
+	Eccentricity         *float64   `json:"eccentricity"`
+	RaanDeg              *float64   `json:"raan_deg"`
+	InclinationDeg       *float64   `json:"inclination_deg"`
+	ArgumentOfPerihelion *float64   `json:"argument_of_perihelion"`
+	TrueAnomalyDeg       *float64   `json:"true_anomaly_deg"`
+	OrbitEpoch           *time.Time `json:"orbit_epoch"`
+	OrbitEpochJD         *float64   `json:"orbit_epoch_jd,omitempty"`    // Та же эпоха, юлианская дата
+	OrbitEpochScale      string     `json:"orbit_epoch_scale,omitempty"` // Шкала orbit_epoch_jd (TDB)
+	OrbitSource          string     `json:"orbit_source"`
+	OrbitMethod          string     `json:"orbit_method,omitempty"`
+	OrbitActual          bool       `json:"orbit_actual"`
+	OriginalInverseA     *float64   `json:"original_inverse_a,omitempty"`
+	FutureInverseA       *float64   `json:"future_inverse_a,omitempty"`
+	DynamicalClass       string     `json:"dynamical_class,omitempty"`
+	DynamicsPending      bool       `json:"dynamics_pending,omitempty"` // 1/a еще не рассчитаны для текущей орбиты
+	// Кандидаты в известные кометы из справочного каталога
+	Identifications []*OrbitIdentification `json:"identifications,omitempty"`
+}
+
+// OrbitIdentification кандидат отождествления орбиты с кометой каталога
+type OrbitIdentification struct {
+	CatalogID   int     `json:"catalog_id"`
+	Designation string  `json:"designation"`
+	Name        string  `json:"name"`
+	DSH         float64 `json:"d_sh"`
+	DDrummond   float64 `json:"d_drummond"`
+	DH          float64 `json:"d_h"`
+}
+
+// MeteorShowerReport связь орбиты кометы с метеорными потоками
+type MeteorShowerReport struct {
+	CometID      int                        `json:"comet_id"`
+	Criterion    string                     `json:"criterion"`
+	Threshold    float64                    `json:"threshold"`
+	Associations []*MeteorShowerAssociation `json:"associations"`
+	Radiants     []*TheoreticalRadiant      `json:"theoretical_radiants"`
+}
+
+// MeteorShowerAssociation сходство орбиты кометы с орбитой потока
+type MeteorShowerAssociation struct {
+	IAUNumber    int     `json:"iau_number"`
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	ParentBody   string  `json:"parent_body"` // Родительское тело по данным IAU MDC
+	DSH          float64 `json:"d_sh"`
+	DDrummond    float64 `json:"d_drummond"`
+	DH           float64 `json:"d_h"`
+	LikelyParent bool    `json:"likely_parent"`
+}
+
+// TheoreticalRadiant ожидаемый радиант метеоров в узле орбиты, близком к орбите Земли
+type TheoreticalRadiant struct {
+	Node               string    `json:"node"` // ascending или descending
+	NodalDistance      float64   `json:"nodal_distance"`
+	MissDistance       float64   `json:"miss_distance"`
+	SolarLongitude     float64   `json:"solar_longitude"`
+	Date               time.Time `json:"date"`
+	RightAscension     float64   `json:"right_ascension"`
+	Declination        float64   `json:"declination"`
+	GeocentricVelocity float64   `json:"geocentric_velocity"` // км/с
+}
+
+// CometClusterReport группы комет с близкими орбитами
+type CometClusterReport struct {
+	Criterion string          `json:"criterion"`
+	Threshold float64         `json:"threshold"`
+	Clusters  []*CometCluster `json:"clusters"`
+}
+
+// CometCluster группа комет, связанных цепочкой пар с расстоянием не выше порога
+type CometCluster struct {
+	Comets []*CometClusterMember `json:"comets"`
+	Pairs  []*CometPairDistance  `json:"pairs"`
+}
+
+type CometClusterMember struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CometPairDistance расстояния между орбитами двух комет группы
+type CometPairDistance struct {
+	CometID      int     `json:"comet_id"`
+	OtherCometID int     `json:"other_comet_id"`
+	DSH          float64 `json:"d_sh"`
+	DDrummond    float64 `json:"d_drummond"`
+	DH           float64 `json:"d_h"`
+}
+
+// SandboxOrbitResponse результат пробного расчета орбиты
+type SandboxOrbitResponse struct {
+	SemiMajorAxis        float64                `json:"semi_major_axis"`
+	Eccentricity         float64                `json:"eccentricity"`
+	RaanDeg              float64                `json:"raan_deg"`
+	InclinationDeg       float64                `json:"inclination_deg"`
+	ArgumentOfPerihelion float64                `json:"argument_of_perihelion"`
+	TrueAnomalyDeg       float64                `json:"true_anomaly_deg"`
+	OrbitEpoch           *time.Time             `json:"orbit_epoch"`
+	OrbitEpochJD         *float64               `json:"orbit_epoch_jd,omitempty"`
+	OrbitEpochScale      string                 `json:"orbit_epoch_scale,omitempty"`
+	OrbitMethod          string                 `json:"orbit_method"`
+	Residuals            []*ObservationResidual `json:"residuals"`
+	RMS                  *float64               `json:"rms"`                      // Среднеквадратичная невязка, угл. сек
+	AccuracyFloor        *float64               `json:"accuracy_floor,omitempty"` // Ошибка модели положения Земли для ближайшего наблюдения, угл. сек
+	Trajectory           *Trajectory            `json:"trajectory,omitempty"`
+}
+
+// ObservationResidual невязка O-C наблюдения относительно орбиты, угловые секунды
+type ObservationResidual struct {
+	Index      int       `json:"index"` // Номер наблюдения в запросе
+	ObservedAt time.Time `json:"observed_at"`
+	DeltaRA    float64   `json:"delta_ra"` // ΔRA·cos(Dec)
+	DeltaDec   float64   `json:"delta_dec"`
+	Total      float64   `json:"total"`
+}
+
+// GeneratedObservationsResponse синтетические наблюдения и, при сохранении, созданная комета
+type GeneratedObservationsResponse struct {
+	Comet        *Comet         `json:"comet,omitempty"`
+	Observations []*Observation `json:"observations"`
 }
 
 type CometDistanceResponse struct {
@@ -61,3 +179,150 @@ type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message,omitempty"`
 }
+
+// OrbitRefinementResponse результат уточнения орбиты методом наименьших квадратов
+type OrbitRefinementResponse struct {
+	Orbit            *CometOrbitResponse `json:"orbit"`
+	Applied          bool                `json:"applied"` // Уточненная орбита сохранена
+	Converged        bool                `json:"converged"`
+	Iterations       int                 `json:"iterations"`
+	ObservationsUsed int                 `json:"observations_used"`
+	InitialRMS       float64             `json:"initial_rms"` // Невязка исходной орбиты, угл. сек
+	RMS              float64             `json:"rms"`         // Невязка уточненной орбиты, угл. сек
+	Uncertainty      *OrbitUncertainty   `json:"uncertainty,omitempty"`
+	// Ковариационная матрица элементов в порядке CovarianceElements
+	Covariance         [][]float64 `json:"covariance,omitempty"`
+	CovarianceElements []string    `json:"covariance_elements,omitempty"`
+}
+
+// OrbitUncertainty среднеквадратичные ошибки (1σ) кеплеровских элементов
+type OrbitUncertainty struct {
+	SemiMajorAxis        float64 `json:"semi_major_axis"`
+	Eccentricity         float64 `json:"eccentricity"`
+	InclinationDeg       float64 `json:"inclination_deg"`
+	RaanDeg              float64 `json:"raan_deg"`
+	ArgumentOfPerihelion float64 `json:"argument_of_perihelion"`
+	TrueAnomalyDeg       float64 `json:"true_anomaly_deg"`
+}
+
+// LightCurveResponse закон блеска кометы m = M1 + 5 lg Δ + 2.5 K1 lg r и прогноз по нему
+type LightCurveResponse struct {
+	CometID      int                    `json:"comet_id"`
+	M1           float64                `json:"m1"`
+	K1           float64                `json:"k1"`
+	SigmaM1      float64                `json:"sigma_m1"`
+	SigmaK1      float64                `json:"sigma_k1"`
+	K1Fixed      bool                   `json:"k1_fixed"` // K1 принят стандартным из-за узкого диапазона r
+	RMS          float64                `json:"rms"`      // Отклонение оценок от закона, зв. вел.
+	Observations []*LightCurvePoint     `json:"observations"`
+	Predictions  []*MagnitudePrediction `json:"predictions"`
+}
+
+// LightCurvePoint оценка блеска с расстояниями из орбиты и отклонением от закона
+type LightCurvePoint struct {
+	ObservationID int       `json:"observation_id"`
+	ObservedAt    time.Time `json:"observed_at"`
+	Magnitude     float64   `json:"magnitude"`
+	Band          string    `json:"band,omitempty"`
+	HelioDistance float64   `json:"helio_distance"` // r, а.е.
+	GeoDistance   float64   `json:"geo_distance"`   // Δ, а.е.
+	Residual      float64   `json:"residual"`       // O−C, зв. вел.
+}
+
+// AfRhoResponse временной ряд пылепроизводительности кометы
+type AfRhoResponse struct {
+	CometID          int           `json:"comet_id"`
+	PhaseCoefficient float64       `json:"phase_coefficient"` // Использованный фазовый коэффициент, зв. вел./градус
+	Points           []*AfRhoPoint `json:"points"`
+}
+
+// AfRhoPoint Afρ по одному измерению блеска в диафрагме
+type AfRhoPoint struct {
+	ObservationID  int       `json:"observation_id"`
+	ObservedAt     time.Time `json:"observed_at"`
+	Magnitude      float64   `json:"magnitude"`
+	Filter         string    `json:"filter"`
+	ApertureArcsec float64   `json:"aperture_radius_arcsec"`
+	RhoKm          float64   `json:"rho_km"`         // Радиус диафрагмы на расстоянии кометы, км
+	HelioDistance  float64   `json:"helio_distance"` // r, а.е.
+	GeoDistance    float64   `json:"geo_distance"`   // Δ, а.е.
+	PhaseAngle     float64   `json:"phase_angle"`    // Градусы
+	AfRho          float64   `json:"afrho"`          // A(θ)fρ, см
+	AfRho0         float64   `json:"afrho_0"`        // A(0°)fρ, см
+}
+
+// MagnitudePrediction расчетная полная звездная величина на дату
+type MagnitudePrediction struct {
+	Date          time.Time `json:"date"`
+	Magnitude     float64   `json:"magnitude"`
+	HelioDistance float64   `json:"helio_distance"`
+	GeoDistance   float64   `json:"geo_distance"`
+}
+
+// CometEvent событие календаря кометы: перигелий, сближение с Землей,
+// соединение или противостояние с Солнцем, максимум расчетного блеска
+type CometEvent struct {
+	CometID       int       `json:"comet_id"`
+	CometName     string    `json:"comet_name"`
+	Type          string    `json:"type"`
+	Date          time.Time `json:"date"`
+	HelioDistance float64   `json:"helio_distance"` // r, а.е.
+	GeoDistance   float64   `json:"geo_distance"`   // Δ, а.е.
+	Elongation    float64   `json:"elongation"`     // Угловое расстояние от Солнца, градусы
+	Magnitude     *float64  `json:"magnitude,omitempty"`
+}
+
+// CalendarFeedResponse ссылка для подписки на календарь событий
+type CalendarFeedResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrbitSchedulerStatus состояние фонового пересчета устаревших орбит
+type OrbitSchedulerStatus struct {
+	Enabled       bool   `json:"enabled"`
+	Interval      string `json:"interval,omitempty"`
+	BatchSize     int    `json:"batch_size,omitempty"`
+	RatePerMinute int    `json:"rate_per_minute,omitempty"`
+	Running       bool   `json:"running"` // Идет ли проход прямо сейчас
+
+	LastRunStartedAt  *time.Time `json:"last_run_started_at,omitempty"`
+	LastRunFinishedAt *time.Time `json:"last_run_finished_at,omitempty"`
+	LastRunFound      int        `json:"last_run_found"` // Устаревших комет в последней выборке
+
+	Recalculated     int64 `json:"recalculated"` // Счетчики с момента запуска сервиса
+	DynamicsComputed int64 `json:"dynamics_computed"`
+	Failed           int64 `json:"failed"`
+	Skipped          int64 `json:"skipped"` // Комета была занята ручным расчетом
+
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorAt   *time.Time `json:"last_error_at,omitempty"`
+	BackoffComets int        `json:"backoff_comets"` // Комет, ожидающих повтора после ошибки
+}
+
+// TrashedComet удаленная комета в корзине
+type TrashedComet struct {
+	Comet
+	ObservationCount int        `json:"observation_count"`  // Наблюдений, удаленных вместе с кометой
+	PurgeAt          *time.Time `json:"purge_at,omitempty"` // Когда комета будет удалена окончательно
+}
+
+// ObservationSuggestion кометы, к которым вероятно относится наблюдение без кометы,
+// по возрастанию отклонения от предвычисленного положения
+type ObservationSuggestion struct {
+	ObservationID int               `json:"observation_id"`
+	ObservedAt    time.Time         `json:"observed_at"`
+	Candidates    []*CometCandidate `json:"candidates"`
+}
+
+// CometCandidate положение кометы на момент наблюдения по ее сохраненной орбите
+type CometCandidate struct {
+	CometID      int     `json:"comet_id"`
+	Name         string  `json:"name"`
+	Designation  *string `json:"designation,omitempty"`
+	PredictedRA  float64 `json:"predicted_ra"`  // Градусы (ICRF)
+	PredictedDec float64 `json:"predicted_dec"` // Градусы
+	Separation   float64 `json:"separation"`    // Отклонение наблюдения от прогноза, градусы
+	OrbitActual  bool    `json:"orbit_actual"`  // false — орбита рассчитана до последних изменений наблюдений
+}
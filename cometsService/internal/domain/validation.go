@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Validate проверяет физическую допустимость кеплеровских элементов.
+// Параболические орбиты (e = 1) не выражаются через большую полуось и отклоняются.
+func (e *OrbitalElements) Validate() error {
+	values := []struct {
+		name  string
+		value float64
+	}{
+		{"semi_major_axis", e.SemiMajorAxis},
+		{"eccentricity", e.Eccentricity},
+		{"raan_deg", e.RaanDeg},
+		{"inclination_deg", e.InclinationDeg},
+		{"argument_of_perihelion", e.ArgumentOfPerihelion},
+		{"true_anomaly_deg", e.TrueAnomalyDeg},
+	}
+	for _, v := range values {
+		if math.IsNaN(v.value) || math.IsInf(v.value, 0) {
+			return fmt.Errorf("%w: %s must be a finite number", ErrInvalidInput, v.name)
+		}
+	}
+
+	switch {
+	case e.Eccentricity < 0:
+		return fmt.Errorf("%w: eccentricity must be non-negative", ErrInvalidInput)
+	case e.Eccentricity == 1:
+		return fmt.Errorf("%w: parabolic orbit (eccentricity = 1) cannot be described by semi_major_axis", ErrInvalidInput)
+	case e.Eccentricity < 1 && e.SemiMajorAxis <= 0:
+		return fmt.Errorf("%w: semi_major_axis must be positive for elliptic orbit", ErrInvalidInput)
+	case e.Eccentricity > 1 && e.SemiMajorAxis >= 0:
+		return fmt.Errorf("%w: semi_major_axis must be negative for hyperbolic orbit", ErrInvalidInput)
+	}
+
+	if e.InclinationDeg < 0 || e.InclinationDeg > 180 {
+		return fmt.Errorf("%w: inclination_deg must be in range [0, 180]", ErrInvalidInput)
+	}
+	if e.RaanDeg < 0 || e.RaanDeg >= 360 {
+		return fmt.Errorf("%w: raan_deg must be in range [0, 360)", ErrInvalidInput)
+	}
+	if e.ArgumentOfPerihelion < 0 || e.ArgumentOfPerihelion >= 360 {
+		return fmt.Errorf("%w: argument_of_perihelion must be in range [0, 360)", ErrInvalidInput)
+	}
+	if e.TrueAnomalyDeg < 0 || e.TrueAnomalyDeg >= 360 {
+		return fmt.Errorf("%w: true_anomaly_deg must be in range [0, 360)", ErrInvalidInput)
+	}
+
+	// На гиперболе истинная аномалия ограничена асимптотами: |ν| < arccos(-1/e)
+	if e.Eccentricity > 1 {
+		nu := e.TrueAnomalyDeg
+		if nu > 180 {
+			nu -= 360
+		}
+		limit := math.Acos(-1/e.Eccentricity) * 180 / math.Pi
+		if math.Abs(nu) >= limit {
+			return fmt.Errorf("%w: true_anomaly_deg is beyond the hyperbola asymptote (|ν| must be < %.4f)", ErrInvalidInput, limit)
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.9
 // 	protoc        v6.30.0
 // source: proto/comet_orbit.proto
 
@@ -26,10 +26,11 @@ const (
 // Одно наблюдение
 type Observation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TimeUtc       string                 `protobuf:"bytes,1,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"` // "YYYY-MM-DD HH:MM:SS"
+	TimeUtc       string                 `protobuf:"bytes,1,opt,name=time_utc,json=timeUtc,proto3" json:"time_utc,omitempty"` // Момент наблюдения в UTC: "YYYY-MM-DD HH:MM:SS"
 	RaDeg         float64                `protobuf:"fixed64,2,opt,name=ra_deg,json=raDeg,proto3" json:"ra_deg,omitempty"`     // Прямое восхождение в градусах
 	DecDeg        float64                `protobuf:"fixed64,3,opt,name=dec_deg,json=decDeg,proto3" json:"dec_deg,omitempty"`  // Склонение в градусах
 	IsHorizontal  bool                   `protobuf:"varint,4,opt,name=isHorizontal,proto3" json:"isHorizontal,omitempty"`
+	JdTt          float64                `protobuf:"fixed64,5,opt,name=jd_tt,json=jdTt,proto3" json:"jd_tt,omitempty"` // Тот же момент: юлианская дата в шкале TT
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -92,6 +93,13 @@ func (x *Observation) GetIsHorizontal() bool {
 	return false
 }
 
+func (x *Observation) GetJdTt() float64 {
+	if x != nil {
+		return x.JdTt
+	}
+	return 0
+}
+
 // Запрос, содержащий список наблюдений
 type ObservationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -146,7 +154,8 @@ type KeplerianElementsResponse struct {
 	RaanDeg           float64                `protobuf:"fixed64,4,opt,name=raan_deg,json=raanDeg,proto3" json:"raan_deg,omitempty"` // Longitude of ascending node
 	ArgOfPeriapsisDeg float64                `protobuf:"fixed64,5,opt,name=arg_of_periapsis_deg,json=argOfPeriapsisDeg,proto3" json:"arg_of_periapsis_deg,omitempty"`
 	TrueAnomalyDeg    float64                `protobuf:"fixed64,6,opt,name=true_anomaly_deg,json=trueAnomalyDeg,proto3" json:"true_anomaly_deg,omitempty"`
-	EpochJd           string                 `protobuf:"bytes,7,opt,name=epoch_jd,json=epochJd,proto3" json:"epoch_jd,omitempty"`
+	EpochJd           string                 `protobuf:"bytes,7,opt,name=epoch_jd,json=epochJd,proto3" json:"epoch_jd,omitempty"`          // Эпоха элементов, юлианская дата в шкале epoch_scale
+	EpochScale        string                 `protobuf:"bytes,8,opt,name=epoch_scale,json=epochScale,proto3" json:"epoch_scale,omitempty"` // Шкала времени epoch_jd: "UTC", "TT" или "TDB"; пусто — UTC
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -230,6 +239,13 @@ func (x *KeplerianElementsResponse) GetEpochJd() string {
 	return ""
 }
 
+func (x *KeplerianElementsResponse) GetEpochScale() string {
+	if x != nil {
+		return x.EpochScale
+	}
+	return ""
+}
+
 // Ответ с информацией о сближении
 type ClosestApproachResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -484,103 +500,53 @@ func (x *TrajectoryResponse) GetEarthTrajectory() []*TrajectoryPoint {
 
 var File_proto_comet_orbit_proto protoreflect.FileDescriptor
 
-var file_proto_comet_orbit_proto_rawDesc = string([]byte{
-	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x5f, 0x6f, 0x72,
-	0x62, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x6d, 0x65, 0x74,
-	0x6f, 0x72, 0x62, 0x69, 0x74, 0x22, 0x7c, 0x0a, 0x0b, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x74, 0x63,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x74, 0x63, 0x12,
-	0x15, 0x0a, 0x06, 0x72, 0x61, 0x5f, 0x64, 0x65, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x05, 0x72, 0x61, 0x44, 0x65, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x65, 0x63, 0x5f, 0x64, 0x65,
-	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x64, 0x65, 0x63, 0x44, 0x65, 0x67, 0x12,
-	0x22, 0x0a, 0x0c, 0x69, 0x73, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e,
-	0x74, 0x61, 0x6c, 0x22, 0x52, 0x0a, 0x13, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x6f, 0x62,
-	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e, 0x4f, 0x62,
-	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72,
-	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa6, 0x02, 0x0a, 0x19, 0x4b, 0x65, 0x70, 0x6c,
-	0x65, 0x72, 0x69, 0x61, 0x6e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x12, 0x73, 0x65, 0x6d, 0x69, 0x5f, 0x6d, 0x61,
-	0x6a, 0x6f, 0x72, 0x5f, 0x61, 0x78, 0x69, 0x73, 0x5f, 0x61, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x0f, 0x73, 0x65, 0x6d, 0x69, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x41, 0x78, 0x69, 0x73,
-	0x41, 0x75, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x63, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x63, 0x69,
-	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x65, 0x63, 0x63, 0x65, 0x6e, 0x74,
-	0x72, 0x69, 0x63, 0x69, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x67, 0x12,
-	0x19, 0x0a, 0x08, 0x72, 0x61, 0x61, 0x6e, 0x5f, 0x64, 0x65, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x07, 0x72, 0x61, 0x61, 0x6e, 0x44, 0x65, 0x67, 0x12, 0x2f, 0x0a, 0x14, 0x61, 0x72,
-	0x67, 0x5f, 0x6f, 0x66, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x61, 0x70, 0x73, 0x69, 0x73, 0x5f, 0x64,
-	0x65, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x61, 0x72, 0x67, 0x4f, 0x66, 0x50,
-	0x65, 0x72, 0x69, 0x61, 0x70, 0x73, 0x69, 0x73, 0x44, 0x65, 0x67, 0x12, 0x28, 0x0a, 0x10, 0x74,
-	0x72, 0x75, 0x65, 0x5f, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x5f, 0x64, 0x65, 0x67, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x74, 0x72, 0x75, 0x65, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
-	0x6c, 0x79, 0x44, 0x65, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6a,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4a, 0x64,
-	0x22, 0x76, 0x0a, 0x17, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x74, 0x41, 0x70, 0x70, 0x72, 0x6f,
-	0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74,
-	0x69, 0x6d, 0x65, 0x5f, 0x75, 0x74, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74,
-	0x69, 0x6d, 0x65, 0x55, 0x74, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x61, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x75, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4b, 0x6d, 0x22, 0xbf, 0x01, 0x0a, 0x11, 0x54, 0x72, 0x61,
-	0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x43,
-	0x0a, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69,
-	0x74, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0c, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x5f, 0x75, 0x74, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x74, 0x63, 0x12, 0x20, 0x0a, 0x0c, 0x65, 0x6e, 0x64,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x74, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x74, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x6e,
-	0x75, 0x6d, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x09, 0x6e, 0x75, 0x6d, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x65, 0x0a, 0x0f, 0x54, 0x72,
-	0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x19, 0x0a,
-	0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x74, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x74, 0x63, 0x12, 0x11, 0x0a, 0x04, 0x78, 0x5f, 0x61, 0x75,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x78, 0x41, 0x75, 0x12, 0x11, 0x0a, 0x04, 0x79,
-	0x5f, 0x61, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x79, 0x41, 0x75, 0x12, 0x11,
-	0x0a, 0x04, 0x7a, 0x5f, 0x61, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x7a, 0x41,
-	0x75, 0x22, 0xa4, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x65,
-	0x74, 0x5f, 0x74, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e,
-	0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
-	0x0f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
-	0x12, 0x46, 0x0a, 0x10, 0x65, 0x61, 0x72, 0x74, 0x68, 0x5f, 0x74, 0x72, 0x61, 0x6a, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d,
-	0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x65, 0x61, 0x72, 0x74, 0x68, 0x54, 0x72,
-	0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x32, 0xa0, 0x02, 0x0a, 0x0c, 0x4f, 0x72, 0x62,
-	0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x64, 0x0a, 0x1a, 0x43, 0x61, 0x6c,
-	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x70, 0x6c, 0x65, 0x72, 0x69, 0x61, 0x6e, 0x45,
-	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f,
-	0x72, 0x62, 0x69, 0x74, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
-	0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e, 0x4b, 0x65, 0x70, 0x6c, 0x65, 0x72, 0x69, 0x61, 0x6e, 0x45,
-	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x5a, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x74, 0x41, 0x70, 0x70,
-	0x72, 0x6f, 0x61, 0x63, 0x68, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62,
-	0x69, 0x74, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72,
-	0x62, 0x69, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x74, 0x41, 0x70, 0x70, 0x72, 0x6f,
-	0x61, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x47,
-	0x65, 0x74, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x2e, 0x63,
-	0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6f,
-	0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6a, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x52, 0x5a, 0x50, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x30, 0x73, 0x68, 0x69, 0x34,
-	0x65, 0x6b, 0x2f, 0x76, 0x30, 0x2e, 0x31, 0x2d, 0x63, 0x61, 0x72, 0x67, 0x6f, 0x2d, 0x63, 0x6f,
-	0x6d, 0x65, 0x74, 0x2d, 0x62, 0x61, 0x63, 0x6b, 0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x73, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x67, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x6f, 0x72, 0x62, 0x69, 0x74, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_proto_comet_orbit_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/comet_orbit.proto\x12\n" +
+	"cometorbit\"\x91\x01\n" +
+	"\vObservation\x12\x19\n" +
+	"\btime_utc\x18\x01 \x01(\tR\atimeUtc\x12\x15\n" +
+	"\x06ra_deg\x18\x02 \x01(\x01R\x05raDeg\x12\x17\n" +
+	"\adec_deg\x18\x03 \x01(\x01R\x06decDeg\x12\"\n" +
+	"\fisHorizontal\x18\x04 \x01(\bR\fisHorizontal\x12\x13\n" +
+	"\x05jd_tt\x18\x05 \x01(\x01R\x04jdTt\"R\n" +
+	"\x13ObservationsRequest\x12;\n" +
+	"\fobservations\x18\x01 \x03(\v2\x17.cometorbit.ObservationR\fobservations\"\xc7\x02\n" +
+	"\x19KeplerianElementsResponse\x12+\n" +
+	"\x12semi_major_axis_au\x18\x01 \x01(\x01R\x0fsemiMajorAxisAu\x12\"\n" +
+	"\feccentricity\x18\x02 \x01(\x01R\feccentricity\x12'\n" +
+	"\x0finclination_deg\x18\x03 \x01(\x01R\x0einclinationDeg\x12\x19\n" +
+	"\braan_deg\x18\x04 \x01(\x01R\araanDeg\x12/\n" +
+	"\x14arg_of_periapsis_deg\x18\x05 \x01(\x01R\x11argOfPeriapsisDeg\x12(\n" +
+	"\x10true_anomaly_deg\x18\x06 \x01(\x01R\x0etrueAnomalyDeg\x12\x19\n" +
+	"\bepoch_jd\x18\a \x01(\tR\aepochJd\x12\x1f\n" +
+	"\vepoch_scale\x18\b \x01(\tR\n" +
+	"epochScale\"v\n" +
+	"\x17ClosestApproachResponse\x12\x19\n" +
+	"\btime_utc\x18\x01 \x01(\tR\atimeUtc\x12\x1f\n" +
+	"\vdistance_au\x18\x02 \x01(\x01R\n" +
+	"distanceAu\x12\x1f\n" +
+	"\vdistance_km\x18\x03 \x01(\x01R\n" +
+	"distanceKm\"\xbf\x01\n" +
+	"\x11TrajectoryRequest\x12C\n" +
+	"\fobservations\x18\x01 \x01(\v2\x1f.cometorbit.ObservationsRequestR\fobservations\x12$\n" +
+	"\x0estart_time_utc\x18\x02 \x01(\tR\fstartTimeUtc\x12 \n" +
+	"\fend_time_utc\x18\x03 \x01(\tR\n" +
+	"endTimeUtc\x12\x1d\n" +
+	"\n" +
+	"num_points\x18\x04 \x01(\x05R\tnumPoints\"e\n" +
+	"\x0fTrajectoryPoint\x12\x19\n" +
+	"\btime_utc\x18\x01 \x01(\tR\atimeUtc\x12\x11\n" +
+	"\x04x_au\x18\x02 \x01(\x01R\x03xAu\x12\x11\n" +
+	"\x04y_au\x18\x03 \x01(\x01R\x03yAu\x12\x11\n" +
+	"\x04z_au\x18\x04 \x01(\x01R\x03zAu\"\xa4\x01\n" +
+	"\x12TrajectoryResponse\x12F\n" +
+	"\x10comet_trajectory\x18\x01 \x03(\v2\x1b.cometorbit.TrajectoryPointR\x0fcometTrajectory\x12F\n" +
+	"\x10earth_trajectory\x18\x02 \x03(\v2\x1b.cometorbit.TrajectoryPointR\x0fearthTrajectory2\xa0\x02\n" +
+	"\fOrbitService\x12d\n" +
+	"\x1aCalculateKeplerianElements\x12\x1f.cometorbit.ObservationsRequest\x1a%.cometorbit.KeplerianElementsResponse\x12Z\n" +
+	"\x12GetClosestApproach\x12\x1f.cometorbit.ObservationsRequest\x1a#.cometorbit.ClosestApproachResponse\x12N\n" +
+	"\rGetTrajectory\x12\x1d.cometorbit.TrajectoryRequest\x1a\x1e.cometorbit.TrajectoryResponseBRZPgithub.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/grpc/cometorbitb\x06proto3"
 
 var (
 	file_proto_comet_orbit_proto_rawDescOnce sync.Once
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Admin handlers
+
+// GetOrbitSchedulerStatus состояние фонового пересчета устаревших орбит
+func (h *CometsHandler) GetOrbitSchedulerStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.cometsService.GetOrbitSchedulerStatus())
+}
+
+// GenerateObservations генерирует синтетические наблюдения для тестирования методов расчета орбит
+func (h *CometsHandler) GenerateObservations(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.GenerateObservationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.cometsService.GenerateObservations(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Comet != nil {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Catalog handlers
+func (h *CometsHandler) SearchCatalog(c *gin.Context) {
+	var req domain.SearchCatalogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comets, err := h.cometsService.SearchCatalog(c.Request.Context(), req.Query, req.Limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comets)
+}
+
+func (h *CometsHandler) GetCatalogComet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.GetCatalogComet(c.Request.Context(), id)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if comet == nil {
+		HandleError(c, domain.ErrNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, comet)
+}
+
+func (h *CometsHandler) CreateCometFromCatalog(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	// Тело запроса необязательно
+	var req domain.CreateCometFromCatalogRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	comet, err := h.cometsService.CreateCometFromCatalog(c.Request.Context(), userID, id, req.Name)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comet)
+}
@@ -34,6 +34,16 @@ func HandleError(c *gin.Context, err error) {
 			Error:   "Bad Request",
 			Message: err.Error(),
 		})
+	case errors.Is(err, domain.ErrUnsupportedArc):
+		c.JSON(http.StatusUnprocessableEntity, domain.ErrorResponse{
+			Error:   "Unprocessable Entity",
+			Message: err.Error(),
+		})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{
+			Error:   "Conflict",
+			Message: err.Error(),
+		})
 	case errors.Is(err, domain.ErrOrbitNotCalculated):
 		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Error:   "Bad Request",
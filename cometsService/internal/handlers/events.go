@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/ical"
+	"github.com/gin-gonic/gin"
+)
+
+// Названия событий в календаре
+var eventTitles = map[string]string{
+	"perihelion":      "Perihelion",
+	"close_approach":  "Closest approach to Earth",
+	"conjunction":     "Conjunction with the Sun",
+	"opposition":      "Opposition",
+	"peak_brightness": "Peak brightness",
+}
+
+// Event calendar handlers
+func (h *CometsHandler) GetCometEvents(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.EventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	events, err := h.cometsService.GetCometEvents(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// GetUserEvents события всех комет пользователя: JSON или iCalendar при format=ics
+func (h *CometsHandler) GetUserEvents(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.EventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	events, err := h.cometsService.GetUserEvents(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if c.Query("format") == "ics" {
+		writeCalendar(c, events)
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+// CreateCalendarFeed выдает ссылку для подписки на календарь в календарном приложении
+func (h *CometsHandler) CreateCalendarFeed(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	feed, err := h.cometsService.CreateCalendarFeed(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.CalendarFeedResponse{
+		Token:     feed.Token,
+		URL:       calendarFeedURL(feed.Token),
+		CreatedAt: feed.CreatedAt,
+	})
+}
+
+func (h *CometsHandler) DeleteCalendarFeed(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if err := h.cometsService.DeleteCalendarFeed(c.Request.Context(), userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// GetCalendarFeed отдает календарь по секретному токену без заголовка авторизации
+func (h *CometsHandler) GetCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	var req domain.EventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	events, err := h.cometsService.GetCalendarFeedEvents(c.Request.Context(), token, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	writeCalendar(c, events)
+}
+
+// calendarFeedURL адрес календаря; внешний адрес сервиса задается PUBLIC_BASE_URL
+func calendarFeedURL(token string) string {
+	return strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/") + "/api/v1/calendar/" + token + ".ics"
+}
+
+func writeCalendar(c *gin.Context, events []*domain.CometEvent) {
+	calendar := ical.Calendar{Name: "Comet events"}
+	for _, e := range events {
+		title, ok := eventTitles[e.Type]
+		if !ok {
+			title = e.Type
+		}
+
+		description := fmt.Sprintf("%s UTC. r = %.3f au, Δ = %.3f au, elongation %.1f°.",
+			e.Date.UTC().Format("2006-01-02 15:04"), e.HelioDistance, e.GeoDistance, e.Elongation)
+		if e.Magnitude != nil {
+			description += fmt.Sprintf(" Predicted magnitude %.1f.", *e.Magnitude)
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         fmt.Sprintf("%s-%d-%s@comets", e.Type, e.CometID, e.Date.UTC().Format("20060102")),
+			Date:        e.Date,
+			Summary:     fmt.Sprintf("%s: %s", title, e.CometName),
+			Description: description,
+		})
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar.Marshal(time.Now()))
+}
@@ -19,19 +19,67 @@ type ICometsHandler interface {
 	GetUserObservationsByCometID(c *gin.Context)
 	UpdateObservation(c *gin.Context)
 	DeleteObservation(c *gin.Context)
+	GetUnassignedObservations(c *gin.Context)
+	SuggestComets(c *gin.Context)
+	AssignObservations(c *gin.Context)
 
 	// Comet handlers
 	CreateComet(c *gin.Context)
 	GetComet(c *gin.Context)
+	LookupComet(c *gin.Context)
 	GetUserComets(c *gin.Context)
+	ClusterUserComets(c *gin.Context)
+	UpdateComet(c *gin.Context)
 	DeleteComet(c *gin.Context)
 	UploadCometPhoto(c *gin.Context)
+	GetMeteorShowers(c *gin.Context)
+	GetLightCurve(c *gin.Context)
+	GetAfRho(c *gin.Context)
+	MergeComets(c *gin.Context)
+	SplitComet(c *gin.Context)
+
+	// Outburst handlers
+	GetOutbursts(c *gin.Context)
+	DetectOutbursts(c *gin.Context)
+	SubscribeOutbursts(c *gin.Context)
+	UnsubscribeOutbursts(c *gin.Context)
+
+	// Trash handlers
+	GetTrash(c *gin.Context)
+	RestoreComet(c *gin.Context)
+	PurgeComet(c *gin.Context)
+
+	// Event calendar handlers
+	GetCometEvents(c *gin.Context)
+	GetUserEvents(c *gin.Context)
+	CreateCalendarFeed(c *gin.Context)
+	DeleteCalendarFeed(c *gin.Context)
+	GetCalendarFeed(c *gin.Context)
+
+	// Scenario handlers
+	CreateScenario(c *gin.Context)
+	GetScenarios(c *gin.Context)
+	DiscardScenario(c *gin.Context)
 
 	// Calculation handlers
 	CalculateOrbit(c *gin.Context)
+	GetOrbitMethods(c *gin.Context)
+	RefineOrbit(c *gin.Context)
 	CalculateCloseApproach(c *gin.Context)
+	CalculateDynamics(c *gin.Context)
 	GetCalculationStatus(c *gin.Context)
 	GetTrajectory(c *gin.Context)
+	IdentifyOrbit(c *gin.Context)
+	SandboxOrbit(c *gin.Context)
+
+	// Admin handlers
+	GenerateObservations(c *gin.Context)
+	GetOrbitSchedulerStatus(c *gin.Context)
+
+	// Catalog handlers
+	SearchCatalog(c *gin.Context)
+	GetCatalogComet(c *gin.Context)
+	CreateCometFromCatalog(c *gin.Context)
 }
 
 type CometsHandler struct {
@@ -105,13 +153,20 @@ func (h *CometsHandler) GetUserObservations(c *gin.Context) {
 		return
 	}
 
-	observations, err := h.cometsService.GetUserObservations(c.Request.Context(), userID)
+	var req domain.ListObservationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	page, err := h.cometsService.GetUserObservations(c.Request.Context(), userID, &req)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, observations)
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, page.Observations)
 }
 
 func (h *CometsHandler) GetUserObservationsByCometID(c *gin.Context) {
@@ -226,7 +281,9 @@ func (h *CometsHandler) CreateComet(c *gin.Context) {
 	}
 	// Если файла нет - это нормально, photoURL будет пустым
 
-	comet, err := h.cometsService.CreateComet(c.Request.Context(), userID, name, fileData, fileName)
+	designation := c.PostForm("designation")
+
+	comet, err := h.cometsService.CreateComet(c.Request.Context(), userID, name, designation, fileData, fileName)
 	if err != nil {
 		c.Error(err) // Логируем в Gin
 		HandleError(c, err)
@@ -234,15 +291,39 @@ func (h *CometsHandler) CreateComet(c *gin.Context) {
 	}
 
 	response := domain.CometCreatedResponse{
-		ID:       comet.ID,
-		UserID:   comet.UserID,
-		Name:     comet.Name,
-		PhotoURL: comet.PhotoURL,
+		ID:          comet.ID,
+		UserID:      comet.UserID,
+		Name:        comet.Name,
+		Designation: comet.Designation,
+		PhotoURL:    comet.PhotoURL,
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
+// LookupComet ищет комету пользователя по обозначению: GET /comets/lookup?designation=c2020f3
+func (h *CometsHandler) LookupComet(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.LookupCometRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.LookupComet(c.Request.Context(), userID, req.Designation)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comet)
+}
+
 func (h *CometsHandler) GetComet(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -271,13 +352,71 @@ func (h *CometsHandler) GetUserComets(c *gin.Context) {
 		return
 	}
 
-	comets, err := h.cometsService.GetUserComets(c.Request.Context(), userID)
+	var req domain.ListCometsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	page, err := h.cometsService.GetUserComets(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, page.Comets)
+}
+
+// ClusterUserComets группирует кометы пользователя с похожими орбитами (фрагменты, семейства)
+func (h *CometsHandler) ClusterUserComets(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.ClusterCometsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	report, err := h.cometsService.ClusterUserComets(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *CometsHandler) UpdateComet(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.UpdateCometRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.UpdateComet(c.Request.Context(), userID, id, &req)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, comets)
+	c.JSON(http.StatusOK, comet)
 }
 
 func (h *CometsHandler) DeleteComet(c *gin.Context) {
@@ -302,6 +441,151 @@ func (h *CometsHandler) DeleteComet(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Comet deleted successfully"})
 }
 
+// GetMeteorShowers ищет метеорные потоки, родительским телом которых может быть комета
+func (h *CometsHandler) GetMeteorShowers(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.MeteorShowerRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	report, err := h.cometsService.GetMeteorShowers(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetLightCurve возвращает закон блеска кометы и прогноз звездной величины
+func (h *CometsHandler) GetLightCurve(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.LightCurveRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	curve, err := h.cometsService.GetLightCurve(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, curve)
+}
+
+// GetAfRho возвращает временной ряд Afρ по диафрагменной фотометрии кометы
+func (h *CometsHandler) GetAfRho(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.AfRhoRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	afrho, err := h.cometsService.GetAfRho(c.Request.Context(), userID, id, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, afrho)
+}
+
+// MergeComets переносит все наблюдения другой кометы в текущую и удаляет ее
+func (h *CometsHandler) MergeComets(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.MergeCometRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.MergeComets(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comet)
+}
+
+// SplitComet переносит выбранные наблюдения кометы в новую комету
+func (h *CometsHandler) SplitComet(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.SplitCometRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.SplitComet(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comet)
+}
+
 // File upload handler для кометы
 func (h *CometsHandler) UploadCometPhoto(c *gin.Context) {
 	userID, err := GetUserIDFromContext(c)
@@ -366,7 +650,19 @@ func (h *CometsHandler) CalculateOrbit(c *gin.Context) {
 		return
 	}
 
-	result, err := h.cometsService.CalculateOrbit(c.Request.Context(), userID, cometID)
+	var req domain.CalculateOrbitRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	result, err := h.cometsService.CalculateOrbit(c.Request.Context(), userID, cometID, req.Method)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -375,6 +671,40 @@ func (h *CometsHandler) CalculateOrbit(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// RefineOrbit уточняет сохраненную орбиту кометы методом наименьших квадратов
+func (h *CometsHandler) RefineOrbit(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.RefineOrbitRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	result, err := h.cometsService.RefineOrbit(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOrbitMethods возвращает доступные методы определения орбиты
+func (h *CometsHandler) GetOrbitMethods(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"methods": h.cometsService.GetOrbitMethods()})
+}
+
 func (h *CometsHandler) CalculateCloseApproach(c *gin.Context) {
 	userID, err := GetUserIDFromContext(c)
 	if err != nil {
@@ -397,6 +727,29 @@ func (h *CometsHandler) CalculateCloseApproach(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// CalculateDynamics вычисляет исходную и будущую 1/a кометы, не дожидаясь планировщика
+func (h *CometsHandler) CalculateDynamics(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	result, err := h.cometsService.CalculateDynamics(c.Request.Context(), userID, cometID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetTrajectory получает траекторию кометы для визуализации
 func (h *CometsHandler) GetTrajectory(c *gin.Context) {
 	userID, err := GetUserIDFromContext(c)
@@ -452,6 +805,61 @@ func (h *CometsHandler) GetTrajectory(c *gin.Context) {
 	c.JSON(http.StatusOK, trajectory)
 }
 
+// IdentifyOrbit подбирает известные кометы каталога с близкой орбитой
+func (h *CometsHandler) IdentifyOrbit(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.IdentifyOrbitRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	identifications, err := h.cometsService.IdentifyOrbit(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identifications)
+}
+
+// SandboxOrbit рассчитывает орбиту по наблюдениям из запроса без сохранения
+func (h *CometsHandler) SandboxOrbit(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.SandboxOrbitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.cometsService.SandboxOrbit(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // func (h *CometsHandler) GetCalculationStatus(c *gin.Context) {
 // 	userID, err := GetUserIDFromContext(c)
 // 	if err != nil {
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"net/http"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
 	"github.com/gin-gonic/gin"
@@ -35,6 +37,27 @@ func AuthMiddleware(authClient domain.IAuthClient) gin.HandlerFunc {
 	}
 }
 
+// AdminMiddleware пропускает только пользователей из списка ADMIN_USER_IDS (через запятую).
+// Должен стоять после AuthMiddleware.
+func AdminMiddleware() gin.HandlerFunc {
+	admins := make(map[int]bool)
+	for _, id := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if v, err := strconv.Atoi(strings.TrimSpace(id)); err == nil {
+			admins[v] = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		userID, err := GetUserIDFromContext(c)
+		if err != nil || !admins[userID] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 // GetUserIDFromContext извлекает userID из контекста
 func GetUserIDFromContext(c *gin.Context) (int, error) {
 	userID, exists := c.Get("userID")
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Outburst handlers
+func (h *CometsHandler) GetOutbursts(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	events, err := h.cometsService.GetOutbursts(c.Request.Context(), userID, cometID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// DetectOutbursts повторяет поиск вспышек, порог можно задать параметром threshold
+func (h *CometsHandler) DetectOutbursts(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.DetectOutburstsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	events, err := h.cometsService.DetectOutbursts(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func (h *CometsHandler) SubscribeOutbursts(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	var req domain.OutburstSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	subscription, err := h.cometsService.SubscribeOutbursts(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *CometsHandler) UnsubscribeOutbursts(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	if err := h.cometsService.UnsubscribeOutbursts(c.Request.Context(), userID, cometID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Outburst subscription removed"})
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPageHeaders передает общее число записей и курсор следующей страницы;
+// на последней странице X-Next-Cursor отсутствует
+func setPageHeaders(c *gin.Context, total int64, nextCursor string) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	if nextCursor != "" {
+		c.Header("X-Next-Cursor", nextCursor)
+	}
+}
@@ -8,6 +8,9 @@ import (
 func SetupRoutes(router *gin.Engine, cometsService domain.ICometsService, authClient domain.IAuthClient) {
 	handler := NewCometsHandler(cometsService)
 
+	// Подписка на календарь: доступ по секретному токену в адресе, без авторизации
+	router.GET("/api/v1/calendar/:token", handler.GetCalendarFeed)
+
 	// Группа маршрутов, требующих аутентификации
 	authGroup := router.Group("/api/v1")
 	authGroup.Use(AuthMiddleware(authClient))
@@ -17,6 +20,12 @@ func SetupRoutes(router *gin.Engine, cometsService domain.ICometsService, authCl
 		{
 			observations.POST("", handler.CreateObservation)
 			observations.GET("", handler.GetUserObservations)
+
+			// Наблюдения без кометы: список, подбор подходящих комет и привязка
+			observations.GET("/unassigned", handler.GetUnassignedObservations)
+			observations.GET("/unassigned/suggestions", handler.SuggestComets)
+			observations.POST("/assign", handler.AssignObservations)
+
 			observations.GET("/:id", handler.GetObservation)
 			observations.PUT("/:id", handler.UpdateObservation)
 			observations.DELETE("/:id", handler.DeleteObservation)
@@ -27,17 +36,76 @@ func SetupRoutes(router *gin.Engine, cometsService domain.ICometsService, authCl
 		{
 			comets.POST("", handler.CreateComet)
 			comets.GET("", handler.GetUserComets)
+			comets.GET("/clusters", handler.ClusterUserComets)
+			comets.GET("/lookup", handler.LookupComet)
 			comets.GET("/:id", handler.GetComet)
+			comets.PATCH("/:id", handler.UpdateComet)
 			comets.DELETE("/:id", handler.DeleteComet)
 			comets.POST("/:comet_id/photo", handler.UploadCometPhoto)
+			comets.POST("/:comet_id/merge", handler.MergeComets)
+			comets.POST("/:comet_id/split", handler.SplitComet)
+
+			// Сценарии: копии кометы с наблюдениями для экспериментов
+			comets.POST("/:comet_id/scenarios", handler.CreateScenario)
+			comets.GET("/:id/scenarios", handler.GetScenarios)
+			comets.DELETE("/:id/scenarios/:scenario_id", handler.DiscardScenario)
+			comets.GET("/:id/meteor-showers", handler.GetMeteorShowers)
+			comets.GET("/:id/light-curve", handler.GetLightCurve)
+			comets.GET("/:id/afrho", handler.GetAfRho)
+
+			// Вспышки блеска и подписка на уведомления о них
+			comets.GET("/:id/outbursts", handler.GetOutbursts)
+			comets.POST("/:comet_id/outbursts/detect", handler.DetectOutbursts)
+			comets.POST("/:comet_id/outbursts/subscription", handler.SubscribeOutbursts)
+			comets.DELETE("/:id/outbursts/subscription", handler.UnsubscribeOutbursts)
+
+			// Календарь событий кометы
+			comets.GET("/:id/events", handler.GetCometEvents)
+		}
+
+		// Корзина: удаленные кометы можно восстановить до окончательного удаления
+		trash := authGroup.Group("/trash")
+		{
+			trash.GET("/comets", handler.GetTrash)
+			trash.POST("/comets/:comet_id/restore", handler.RestoreComet)
+			trash.DELETE("/comets/:id", handler.PurgeComet)
+		}
+
+		// Календарь событий всех комет пользователя (format=ics для iCalendar)
+		events := authGroup.Group("/events")
+		{
+			events.GET("", handler.GetUserEvents)
+			events.POST("/feed", handler.CreateCalendarFeed)
+			events.DELETE("/feed", handler.DeleteCalendarFeed)
 		}
 
 		// Calculation routes
 		calculations := authGroup.Group("/calculations")
 		{
+			calculations.GET("/methods", handler.GetOrbitMethods)
+			calculations.POST("/sandbox", handler.SandboxOrbit)
 			calculations.POST("/:comet_id/orbit", handler.CalculateOrbit)
+			calculations.POST("/:comet_id/orbit/refine", handler.RefineOrbit)
 			calculations.POST("/:comet_id/close-approach", handler.CalculateCloseApproach)
+			calculations.POST("/:comet_id/dynamics", handler.CalculateDynamics)
 			calculations.GET("/:comet_id/trajectory", handler.GetTrajectory)
+			calculations.GET("/:comet_id/identifications", handler.IdentifyOrbit)
+		}
+
+		// Catalog routes (справочник известных комет, только чтение)
+		catalog := authGroup.Group("/catalog")
+		{
+			catalog.GET("", handler.SearchCatalog)
+			catalog.GET("/:id", handler.GetCatalogComet)
+			catalog.POST("/:id/comet", handler.CreateCometFromCatalog)
+		}
+
+		// Admin routes
+		admin := authGroup.Group("/admin")
+		admin.Use(AdminMiddleware())
+		{
+			admin.POST("/synthetic-observations", handler.GenerateObservations)
+			admin.GET("/orbit-scheduler", handler.GetOrbitSchedulerStatus)
 		}
 
 		// Specific observation routes by comet
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Scenario handlers
+func (h *CometsHandler) CreateScenario(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("comet_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	// Тело запроса необязательно
+	var req domain.CreateScenarioRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	scenario, err := h.cometsService.CreateScenario(c.Request.Context(), userID, cometID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, scenario)
+}
+
+func (h *CometsHandler) GetScenarios(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	scenarios, err := h.cometsService.GetScenarios(c.Request.Context(), userID, cometID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scenarios)
+}
+
+func (h *CometsHandler) DiscardScenario(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	cometID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	scenarioID, err := strconv.Atoi(c.Param("scenario_id"))
+	if err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	err = h.cometsService.DiscardScenario(c.Request.Context(), userID, cometID, scenarioID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scenario discarded successfully"})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Unassigned observation handlers
+func (h *CometsHandler) GetUnassignedObservations(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.ListUnassignedObservationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	page, err := h.cometsService.GetUnassignedObservations(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, page.Observations)
+}
+
+// SuggestComets предлагает кометы для страницы наблюдений без кометы;
+// страницы перелистываются так же, как в списке наблюдений без кометы
+func (h *CometsHandler) SuggestComets(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.SuggestCometsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	suggestions, page, err := h.cometsService.SuggestComets(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	setPageHeaders(c, page.Total, page.NextCursor)
+	c.JSON(http.StatusOK, suggestions)
+}
+
+func (h *CometsHandler) AssignObservations(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	var req domain.AssignObservationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	comet, err := h.cometsService.AssignObservations(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comet)
+}
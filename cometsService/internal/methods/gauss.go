@@ -0,0 +1,221 @@
+package methods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	// Метод Гаусса рассчитан на короткие дуги: на длинных ряды для f и g расходятся
+	gaussMinArc = 12 * time.Hour
+	gaussMaxArc = 120 * 24 * time.Hour
+)
+
+// ErrNotSupported операция не поддерживается локальным методом
+var ErrNotSupported = errors.New("operation is not supported by orbit method")
+
+// GaussClient локальное определение предварительной орбиты методом Гаусса.
+// Тройки наблюдений берутся из всей дуги и ее частей; из всех корней уравнения Лагранжа
+// выбирается орбита с наименьшей невязкой по всем экваториальным наблюдениям.
+// Наблюдатель считается находящимся в центре Земли.
+type GaussClient struct{}
+
+func NewGaussClient() *GaussClient {
+	return &GaussClient{}
+}
+
+// CheckArc проверяет, что дуга подходит для метода Гаусса
+func (g *GaussClient) CheckArc(observations []*domain.Observation) error {
+	return checkArc("gauss", observations, 3, gaussMinArc, gaussMaxArc)
+}
+
+func (g *GaussClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	if err := g.CheckArc(observations); err != nil {
+		return nil, err
+	}
+
+	equatorial := equatorialObservations(observations)
+
+	// Перебираем тройки из всей дуги и ее частей: на длинной дуге итерации Гаусса
+	// могут уйти к ложному корню, на короткой сильнее сказываются ошибки наблюдений
+	var solutions []orbit.GaussSolution
+	for _, triple := range gaussTriples(equatorial) {
+		if found, err := orbit.Gauss(triple); err == nil {
+			solutions = append(solutions, found...)
+		}
+	}
+
+	best := bestSolution(solutions, equatorial)
+	if best == nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, orbit.ErrNoGaussSolution)
+	}
+	return best, nil
+}
+
+func (g *GaussClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return nil, ErrNotSupported
+}
+
+func (g *GaussClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return nil, ErrNotSupported
+}
+
+// equatorialObservations наблюдения в экваториальных координатах, упорядоченные по времени
+func equatorialObservations(observations []*domain.Observation) []*domain.Observation {
+	result := make([]*domain.Observation, 0, len(observations))
+	for _, obs := range observations {
+		if !obs.IsHorizontal {
+			result = append(result, obs)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ObservedAt.Before(result[j].ObservedAt)
+	})
+	return result
+}
+
+// gaussTriples тройки наблюдений (крайние и ближайшее к середине) для всей дуги,
+// ее половин и средней части
+func gaussTriples(sorted []*domain.Observation) [][3]orbit.AngleObservation {
+	first, last := sorted[0].ObservedAt, sorted[len(sorted)-1].ObservedAt
+	arc := last.Sub(first)
+
+	windows := [][2]time.Time{
+		{first, last},
+		{first, first.Add(arc / 2)},
+		{first.Add(arc / 4), last.Add(-arc / 4)},
+		{last.Add(-arc / 2), last},
+	}
+
+	var triples [][3]orbit.AngleObservation
+	for _, w := range windows {
+		var inWindow []*domain.Observation
+		for _, obs := range sorted {
+			if !obs.ObservedAt.Before(w[0]) && !obs.ObservedAt.After(w[1]) {
+				inWindow = append(inWindow, obs)
+			}
+		}
+		if len(inWindow) < 3 || inWindow[len(inWindow)-1].ObservedAt.Sub(inWindow[0].ObservedAt) < gaussMinArc {
+			continue
+		}
+
+		var triple [3]orbit.AngleObservation
+		for i, obs := range []*domain.Observation{inWindow[0], middleObservation(inWindow), inWindow[len(inWindow)-1]} {
+			triple[i] = orbit.AngleObservation{
+				JD:  orbit.JulianDate(obs.ObservedAt),
+				RA:  obs.RightAscension,
+				Dec: obs.Declination,
+			}
+		}
+		triples = append(triples, triple)
+	}
+	return triples
+}
+
+// middleObservation внутреннее наблюдение, ближайшее к середине дуги
+func middleObservation(sorted []*domain.Observation) *domain.Observation {
+	first, last := sorted[0].ObservedAt, sorted[len(sorted)-1].ObservedAt
+	mid := first.Add(last.Sub(first) / 2)
+
+	best := sorted[1]
+	for _, obs := range sorted[1 : len(sorted)-1] {
+		if absDuration(obs.ObservedAt.Sub(mid)) < absDuration(best.ObservedAt.Sub(mid)) {
+			best = obs
+		}
+	}
+	return best
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// angleObservations упорядоченные экваториальные наблюдения в виде для pkg/orbit
+func angleObservations(equatorial []*domain.Observation) []orbit.AngleObservation {
+	angles := make([]orbit.AngleObservation, len(equatorial))
+	for i, obs := range equatorial {
+		angles[i] = orbit.AngleObservation{
+			JD:  orbit.JulianDate(obs.ObservedAt),
+			RA:  obs.RightAscension,
+			Dec: obs.Declination,
+		}
+	}
+	return angles
+}
+
+// bestSolution выбирает из решений орбиту с наименьшей невязкой по всем наблюдениям;
+// nil, если ни одно решение не переводится в кеплеровские элементы
+func bestSolution(solutions []orbit.GaussSolution, equatorial []*domain.Observation) *domain.OrbitalElements {
+	var best *domain.OrbitalElements
+	bestRMS := math.Inf(1)
+	for _, solution := range solutions {
+		el, err := orbit.ElementsFromState(solution.State, solution.JD)
+		if err != nil {
+			continue
+		}
+		elements, err := toOrbitalElements(el, solution.JD)
+		if err != nil {
+			continue
+		}
+		if rms := residualRMS(el, equatorial); rms < bestRMS {
+			best, bestRMS = elements, rms
+		}
+	}
+	return best
+}
+
+// checkArc проверяет число экваториальных наблюдений и длину дуги для метода name
+func checkArc(name string, observations []*domain.Observation, minObservations int, minArc, maxArc time.Duration) error {
+	equatorial := equatorialObservations(observations)
+	if len(equatorial) < minObservations {
+		return domain.ErrNotEnoughObservations
+	}
+
+	arc := equatorial[len(equatorial)-1].ObservedAt.Sub(equatorial[0].ObservedAt)
+	if arc < minArc || arc > maxArc {
+		return fmt.Errorf("%w: %s method needs an arc between %s and %s, got %s",
+			domain.ErrUnsupportedArc, name, minArc, maxArc, arc.Round(time.Hour))
+	}
+	return nil
+}
+
+// toOrbitalElements переводит перигелийные элементы в кеплеровские на эпоху jd
+func toOrbitalElements(el orbit.Elements, jd float64) (*domain.OrbitalElements, error) {
+	a, err := el.SemiMajorAxis()
+	if err != nil {
+		return nil, err
+	}
+	nu, _ := el.TrueAnomaly(jd)
+	epoch := orbit.TimeFromJulianDate(jd)
+
+	return &domain.OrbitalElements{
+		SemiMajorAxis:        a,
+		Eccentricity:         el.E,
+		RaanDeg:              el.Node,
+		InclinationDeg:       el.I,
+		ArgumentOfPerihelion: el.Peri,
+		TrueAnomalyDeg:       nu,
+		Epoch:                &epoch,
+	}, nil
+}
+
+// residualRMS среднеквадратичная невязка орбиты по наблюдениям, угловые секунды
+func residualRMS(el orbit.Elements, observations []*domain.Observation) float64 {
+	var sum float64
+	for _, obs := range observations {
+		eph := el.EphemerisAt(orbit.JulianDate(obs.ObservedAt))
+		sep := orbit.AngularSeparation(obs.RightAscension, obs.Declination, eph.RA, eph.Dec) * 3600
+		sum += sep * sep
+	}
+	return math.Sqrt(sum / float64(len(observations)))
+}
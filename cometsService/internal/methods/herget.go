@@ -0,0 +1,58 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	// Задача Ламберта решается без полных оборотов, поэтому дуга должна быть
+	// заметно короче периода
+	hergetMinArc = 12 * time.Hour
+	hergetMaxArc = 365 * 24 * time.Hour
+)
+
+// HergetClient локальное определение орбиты методом Хергета: подбираются
+// геоцентрические расстояния на концах дуги по невязкам всех экваториальных
+// наблюдений. Подходит для дуг длиннее, чем допускает метод Гаусса.
+// Наблюдатель считается находящимся в центре Земли.
+type HergetClient struct{}
+
+func NewHergetClient() *HergetClient {
+	return &HergetClient{}
+}
+
+// CheckArc проверяет, что дуга подходит для метода Хергета
+func (h *HergetClient) CheckArc(observations []*domain.Observation) error {
+	return checkArc("herget", observations, 3, hergetMinArc, hergetMaxArc)
+}
+
+func (h *HergetClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	if err := h.CheckArc(observations); err != nil {
+		return nil, err
+	}
+
+	equatorial := equatorialObservations(observations)
+	solution, err := orbit.Herget(angleObservations(equatorial))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, err)
+	}
+
+	best := bestSolution([]orbit.GaussSolution{solution}, equatorial)
+	if best == nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, orbit.ErrNoHergetSolution)
+	}
+	return best, nil
+}
+
+func (h *HergetClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return nil, ErrNotSupported
+}
+
+func (h *HergetClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return nil, ErrNotSupported
+}
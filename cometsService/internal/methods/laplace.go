@@ -0,0 +1,57 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	// Метод Лапласа аппроксимирует направление на тело квадратичной функцией времени,
+	// что на длинной дуге уже неверно
+	laplaceMinArc = 12 * time.Hour
+	laplaceMaxArc = 60 * 24 * time.Hour
+)
+
+// LaplaceClient локальное определение предварительной орбиты методом Лапласа
+// по всем экваториальным наблюдениям дуги. Наблюдатель считается находящимся
+// в центре Земли.
+type LaplaceClient struct{}
+
+func NewLaplaceClient() *LaplaceClient {
+	return &LaplaceClient{}
+}
+
+// CheckArc проверяет, что дуга подходит для метода Лапласа
+func (l *LaplaceClient) CheckArc(observations []*domain.Observation) error {
+	return checkArc("laplace", observations, 3, laplaceMinArc, laplaceMaxArc)
+}
+
+func (l *LaplaceClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	if err := l.CheckArc(observations); err != nil {
+		return nil, err
+	}
+
+	equatorial := equatorialObservations(observations)
+	solutions, err := orbit.Laplace(angleObservations(equatorial))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, err)
+	}
+
+	best := bestSolution(solutions, equatorial)
+	if best == nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, orbit.ErrNoLaplaceSolution)
+	}
+	return best, nil
+}
+
+func (l *LaplaceClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return nil, ErrNotSupported
+}
+
+func (l *LaplaceClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,72 @@
+package methods
+
+import (
+	"context"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const refineIterations = 25
+
+// RefinedClient уточняет предварительную орбиту другого метода методом наименьших
+// квадратов по всем экваториальным наблюдениям. Если уточнение не сошлось или
+// ухудшило невязку, возвращается исходное решение.
+type RefinedClient struct {
+	base domain.IOrbitCalculationClient
+}
+
+func NewRefinedClient(base domain.IOrbitCalculationClient) *RefinedClient {
+	return &RefinedClient{base: base}
+}
+
+// CheckArc применимость определяется исходным методом
+func (r *RefinedClient) CheckArc(observations []*domain.Observation) error {
+	if checker, ok := r.base.(domain.IOrbitArcChecker); ok {
+		return checker.CheckArc(observations)
+	}
+	return nil
+}
+
+func (r *RefinedClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	preliminary, err := r.base.CalculateOrbit(ctx, observations)
+	if err != nil || preliminary.Epoch == nil {
+		return preliminary, err
+	}
+
+	jd := orbit.JulianDate(*preliminary.Epoch)
+	initial, err := orbit.FromKeplerian(
+		preliminary.SemiMajorAxis,
+		preliminary.Eccentricity,
+		preliminary.InclinationDeg,
+		preliminary.RaanDeg,
+		preliminary.ArgumentOfPerihelion,
+		preliminary.TrueAnomalyDeg,
+		jd,
+	)
+	if err != nil {
+		return preliminary, nil
+	}
+
+	angles := angleObservations(equatorialObservations(observations))
+
+	correction, err := orbit.DifferentialCorrection(initial, jd, angles, refineIterations)
+	if err != nil || !correction.Converged || correction.RMS > correction.InitialRMS {
+		return preliminary, nil
+	}
+
+	refined, err := toOrbitalElements(correction.Elements, jd)
+	if err != nil {
+		return preliminary, nil
+	}
+	return refined, nil
+}
+
+func (r *RefinedClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return r.base.CalculateCloseApproach(ctx, observations)
+}
+
+func (r *RefinedClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return r.base.GetTrajectory(ctx, observations, startTime, endTime, numPoints)
+}
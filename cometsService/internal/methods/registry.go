@@ -0,0 +1,128 @@
+// Package methods содержит реестр методов определения орбиты и локальные реализации
+// domain.IOrbitCalculationClient, работающие без внешнего сервиса.
+package methods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+// Registry реестр методов определения орбиты по имени.
+// Порядок регистрации задает порядок перебора при автоматическом выборе метода.
+type Registry struct {
+	mu      sync.RWMutex
+	names   []string
+	clients map[string]domain.IOrbitCalculationClient
+}
+
+func NewRegistry() *Registry {
+	return &Registry{clients: make(map[string]domain.IOrbitCalculationClient)}
+}
+
+// Register добавляет метод; повторная регистрация заменяет реализацию, не меняя порядок
+func (r *Registry) Register(name string, client domain.IOrbitCalculationClient) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clients[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.clients[name] = client
+}
+
+// RegisterLocal регистрирует локальные методы в порядке перебора: Гаусс с уточнением
+// и без него для средних дуг, Лаплас, Хергет для длинных дуг и Вяйсяля для
+// дуг, слишком коротких для остальных
+func RegisterLocal(r *Registry) {
+	r.Register("gauss+lsq", NewRefinedClient(NewGaussClient()))
+	r.Register("gauss", NewGaussClient())
+	r.Register("laplace", NewLaplaceClient())
+	r.Register("herget", NewHergetClient())
+	r.Register("vaisala", NewVaisalaClient())
+}
+
+// Names возвращает имена методов в порядке перебора
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.names))
+	copy(names, r.names)
+	return names
+}
+
+// Client возвращает реализацию метода по имени
+func (r *Registry) Client(name string) (domain.IOrbitCalculationClient, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	client, ok := r.clients[name]
+	return client, ok
+}
+
+// CalculateOrbit определяет орбиту запрошенным методом. Если метод не справляется с дугой
+// наблюдений, перебираются остальные методы в порядке регистрации. Пустое имя означает
+// первый подходящий метод. Возвращает элементы и имя метода, который их получил.
+func (r *Registry) CalculateOrbit(ctx context.Context, method string, observations []*domain.Observation) (*domain.OrbitalElements, string, error) {
+	candidates := r.Names()
+	if method != "" {
+		if _, ok := r.Client(method); !ok {
+			return nil, "", fmt.Errorf("%w: unknown orbit method %q", domain.ErrInvalidInput, method)
+		}
+		candidates = append([]string{method}, without(candidates, method)...)
+	}
+	if len(candidates) == 0 {
+		return nil, "", fmt.Errorf("no orbit methods registered")
+	}
+
+	var failures []string
+	for _, name := range candidates {
+		client, _ := r.Client(name)
+
+		if checker, ok := client.(domain.IOrbitArcChecker); ok {
+			if err := checker.CheckArc(observations); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+		}
+
+		elements, err := client.CalculateOrbit(ctx, observations)
+		if err == nil {
+			err = elements.Validate()
+		}
+		if err == nil {
+			return elements, name, nil
+		}
+
+		// Переходим к следующему методу только если текущий не справился с дугой;
+		// прочие ошибки (недоступность сервиса, отмена запроса) возвращаются сразу
+		if !isArcError(err) {
+			return nil, "", fmt.Errorf("%s: %w", name, err)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+	}
+
+	return nil, "", fmt.Errorf("%w: no orbit method could handle the observations (%s)", domain.ErrUnsupportedArc, strings.Join(failures, "; "))
+}
+
+// isArcError ошибка означает, что метод неприменим к данной дуге или дал недопустимую орбиту
+func isArcError(err error) bool {
+	return errors.Is(err, domain.ErrUnsupportedArc) ||
+		errors.Is(err, domain.ErrNotEnoughObservations) ||
+		errors.Is(err, domain.ErrInvalidInput)
+}
+
+func without(names []string, name string) []string {
+	result := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	return result
+}
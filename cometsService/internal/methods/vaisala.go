@@ -0,0 +1,57 @@
+package methods
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	// Метод Вяйсяля нужен для дуг, слишком коротких для Гаусса; на длинной дуге
+	// предположение о перигелии в середине дуги дает заметную невязку
+	vaisalaMinArc = time.Hour
+	vaisalaMaxArc = 30 * 24 * time.Hour
+)
+
+// VaisalaClient локальное определение орбиты по очень короткой дуге методом Вяйсяля
+// в предположении, что в середине дуги тело проходит перигелий. Достаточно двух
+// экваториальных наблюдений. Наблюдатель считается находящимся в центре Земли.
+type VaisalaClient struct{}
+
+func NewVaisalaClient() *VaisalaClient {
+	return &VaisalaClient{}
+}
+
+// CheckArc проверяет, что дуга подходит для метода Вяйсяля
+func (v *VaisalaClient) CheckArc(observations []*domain.Observation) error {
+	return checkArc("vaisala", observations, 2, vaisalaMinArc, vaisalaMaxArc)
+}
+
+func (v *VaisalaClient) CalculateOrbit(ctx context.Context, observations []*domain.Observation) (*domain.OrbitalElements, error) {
+	if err := v.CheckArc(observations); err != nil {
+		return nil, err
+	}
+
+	equatorial := equatorialObservations(observations)
+	solution, err := orbit.Vaisala(angleObservations(equatorial))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, err)
+	}
+
+	best := bestSolution([]orbit.GaussSolution{solution}, equatorial)
+	if best == nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedArc, orbit.ErrNoVaisalaSolution)
+	}
+	return best, nil
+}
+
+func (v *VaisalaClient) CalculateCloseApproach(ctx context.Context, observations []*domain.Observation) (*domain.CloseApproach, error) {
+	return nil, ErrNotSupported
+}
+
+func (v *VaisalaClient) GetTrajectory(ctx context.Context, observations []*domain.Observation, startTime, endTime time.Time, numPoints int) (*domain.Trajectory, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertCalendarFeed создает ссылку на календарь или заменяет токен существующей
+func (r *CometsRepository) UpsertCalendarFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"token", "created_at"}),
+		}).
+		Create(feed).Error
+}
+
+func (r *CometsRepository) GetCalendarFeedByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	result := r.db.WithContext(ctx).Where("token = ?", token).First(&feed)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &feed, nil
+}
+
+func (r *CometsRepository) DeleteCalendarFeed(ctx context.Context, userID int) error {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.CalendarFeed{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
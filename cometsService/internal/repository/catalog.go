@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertCatalogComets загружает записи каталога, обновляя существующие по обозначению
+func (r *CometsRepository) UpsertCatalogComets(ctx context.Context, comets []*domain.CatalogComet) error {
+	if len(comets) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "designation"}},
+			UpdateAll: true,
+		}).
+		CreateInBatches(comets, 500).Error
+}
+
+func (r *CometsRepository) GetCatalogCometByID(ctx context.Context, id int) (*domain.CatalogComet, error) {
+	var comet domain.CatalogComet
+	result := r.db.WithContext(ctx).First(&comet, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &comet, nil
+}
+
+// SearchCatalogComets ищет кометы каталога по подстроке обозначения или имени
+func (r *CometsRepository) SearchCatalogComets(ctx context.Context, query string, limit int) ([]*domain.CatalogComet, error) {
+	var comets []*domain.CatalogComet
+	db := r.db.WithContext(ctx)
+
+	if query = strings.TrimSpace(query); query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		db = db.Where("designation ILIKE ? OR name ILIKE ?", pattern, pattern)
+	}
+
+	err := db.Order("designation ASC").Limit(limit).Find(&comets).Error
+	return comets, err
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (r *CometsRepository) GetAllCatalogComets(ctx context.Context) ([]*domain.CatalogComet, error) {
+	var comets []*domain.CatalogComet
+	err := r.db.WithContext(ctx).Order("designation ASC").Find(&comets).Error
+	return comets, err
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+)
+
+// sortColumn столбец сортировки списка и разбор его значения из курсора
+type sortColumn struct {
+	column string
+	parse  func(string) (interface{}, error)
+}
+
+var cometSortColumns = map[string]sortColumn{
+	"id":              {"comets.id", parseIntValue},
+	"name":            {"comets.name", parseStringValue},
+	"calculated_at":   {"comets.calculated_at", parseTimeValue},
+	"eccentricity":    {"comets.eccentricity", parseFloatValue},
+	"semi_major_axis": {"comets.semi_major_axis", parseFloatValue},
+}
+
+var observationSortColumns = map[string]sortColumn{
+	"observed_at": {"observations.observed_at", parseTimeValue},
+	"id":          {"observations.id", parseIntValue},
+}
+
+// ListComets страница комет пользователя по фильтру; сценарии и удаленные кометы не входят
+func (r *CometsRepository) ListComets(ctx context.Context, filter domain.CometFilter, page domain.PageRequest) (*domain.CometPage, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Comet{}).
+		Where("comets.user_id = ? AND comets.deleted_at IS NULL AND comets.scenario_of IS NULL", filter.UserID)
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("comets.name ILIKE ? OR comets.designation ILIKE ?", pattern, pattern)
+	}
+	if filter.OrbitActual != nil {
+		query = query.Where("comets.orbit_actual = ?", *filter.OrbitActual)
+	}
+	switch filter.OrbitClass {
+	case domain.OrbitClassShortPeriod:
+		query = query.Where("comets.eccentricity < 1 AND comets.semi_major_axis > 0 AND comets.semi_major_axis < ?", domain.ShortPeriodMaxSemiMajorAxis)
+	case domain.OrbitClassLongPeriod:
+		query = query.Where("comets.eccentricity < 1 AND comets.semi_major_axis >= ?", domain.ShortPeriodMaxSemiMajorAxis)
+	case domain.OrbitClassHyperbolic:
+		query = query.Where("comets.eccentricity > 1")
+	case domain.OrbitClassNone:
+		query = query.Where("comets.semi_major_axis = 0")
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paged, err := applyPage(query, cometSortColumns, "comets.id", page)
+	if err != nil {
+		return nil, err
+	}
+	var comets []*domain.Comet
+	if err := paged.Find(&comets).Error; err != nil {
+		return nil, err
+	}
+
+	result := &domain.CometPage{Total: total, Comets: comets}
+	if len(comets) > page.Limit {
+		result.Comets = comets[:page.Limit]
+		last := result.Comets[page.Limit-1]
+		result.NextCursor = domain.PageCursor{Value: cometSortValue(last, page.Sort), ID: last.ID}.Encode()
+	}
+	return result, nil
+}
+
+// ListObservations страница наблюдений пользователя одним запросом. Как и раньше,
+// в список входят наблюдения действующих комет пользователя, без сценариев;
+// с filter.Unassigned — только наблюдения, не привязанные к комете.
+func (r *CometsRepository) ListObservations(ctx context.Context, filter domain.ObservationFilter, page domain.PageRequest) (*domain.ObservationPage, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Observation{}).
+		Where("observations.user_id = ? AND observations.deleted_at IS NULL", filter.UserID)
+	if filter.Unassigned {
+		query = query.Where("observations.comet_id IS NULL")
+	} else {
+		query = query.Joins("JOIN comets ON comets.id = observations.comet_id AND comets.deleted_at IS NULL AND comets.scenario_of IS NULL")
+	}
+
+	if filter.CometID != nil {
+		query = query.Where("observations.comet_id = ?", *filter.CometID)
+	}
+	if filter.From != nil {
+		query = query.Where("observations.observed_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("observations.observed_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	paged, err := applyPage(query, observationSortColumns, "observations.id", page)
+	if err != nil {
+		return nil, err
+	}
+	var observations []*domain.Observation
+	if err := paged.Select("observations.*").Find(&observations).Error; err != nil {
+		return nil, err
+	}
+
+	result := &domain.ObservationPage{Total: total, Observations: observations}
+	if len(observations) > page.Limit {
+		result.Observations = observations[:page.Limit]
+		last := result.Observations[page.Limit-1]
+		result.NextCursor = domain.PageCursor{Value: observationSortValue(last, page.Sort), ID: last.ID}.Encode()
+	}
+	return result, nil
+}
+
+// applyPage добавляет к запросу условие курсора (keyset), сортировку с ID как
+// вторым ключом и лимит на одну запись больше страницы, чтобы узнать о следующей
+func applyPage(query *gorm.DB, columns map[string]sortColumn, idColumn string, page domain.PageRequest) (*gorm.DB, error) {
+	sort, ok := columns[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort field %q", domain.ErrInvalidInput, page.Sort)
+	}
+
+	op, dir := ">", "ASC"
+	if page.Desc {
+		op, dir = "<", "DESC"
+	}
+
+	if page.Cursor != nil {
+		value, err := sort.parse(page.Cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cursor does not match sort field %q", domain.ErrInvalidInput, page.Sort)
+		}
+		query = query.Where(fmt.Sprintf("(%s %s ?) OR (%s = ? AND %s %s ?)", sort.column, op, sort.column, idColumn, op),
+			value, value, page.Cursor.ID)
+	}
+
+	return query.
+		Order(fmt.Sprintf("%s %s, %s %s", sort.column, dir, idColumn, dir)).
+		Limit(page.Limit + 1), nil
+}
+
+func cometSortValue(c *domain.Comet, field string) string {
+	switch field {
+	case "name":
+		return c.Name
+	case "calculated_at":
+		return c.CalculatedAt.UTC().Format(time.RFC3339Nano)
+	case "eccentricity":
+		return strconv.FormatFloat(c.Eccentricity, 'g', -1, 64)
+	case "semi_major_axis":
+		return strconv.FormatFloat(c.SemiMajorAxis, 'g', -1, 64)
+	default:
+		return strconv.Itoa(c.ID)
+	}
+}
+
+func observationSortValue(o *domain.Observation, field string) string {
+	if field == "observed_at" {
+		return o.ObservedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return strconv.Itoa(o.ID)
+}
+
+func parseIntValue(s string) (interface{}, error)    { return strconv.Atoi(s) }
+func parseStringValue(s string) (interface{}, error) { return s, nil }
+func parseFloatValue(s string) (interface{}, error)  { return strconv.ParseFloat(s, 64) }
+func parseTimeValue(s string) (interface{}, error)   { return time.Parse(time.RFC3339Nano, s) }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+)
+
+// MergeComets в одной транзакции переносит наблюдения пользователя из источника в целевую
+// комету, отправляет источник в корзину тем же каскадом, что и DeleteComets (вместе со
+// сценариями), сбрасывает флаги расчетов цели и пишет запись аудита.
+// Чужие наблюдения, привязанные к источнику, остаются при нем.
+func (r *CometsRepository) MergeComets(ctx context.Context, targetID, sourceID int, userID int, audit *domain.AuditEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Observation{}).
+			Where("comet_id = ? AND user_id = ? AND deleted_at IS NULL", sourceID, userID).
+			Update("comet_id", targetID).Error; err != nil {
+			return err
+		}
+
+		if err := trashComet(tx, sourceID, userID); err != nil {
+			return err
+		}
+
+		if err := resetCalculationFlags(tx, targetID); err != nil {
+			return err
+		}
+
+		return tx.Create(audit).Error
+	})
+}
+
+// SplitComet в одной транзакции создает новую комету, переносит в нее выбранные
+// наблюдения пользователя из исходной кометы и пишет запись аудита
+func (r *CometsRepository) SplitComet(ctx context.Context, sourceID int, userID int, observationIDs []int, newComet *domain.Comet, audit *domain.AuditEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newComet).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Observation{}).
+			Where("id IN ? AND comet_id = ? AND user_id = ? AND deleted_at IS NULL", observationIDs, sourceID, userID).
+			Update("comet_id", newComet.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(observationIDs)) {
+			return fmt.Errorf("%w: some observations do not belong to comet %d", domain.ErrInvalidInput, sourceID)
+		}
+
+		if err := resetCalculationFlags(tx, sourceID); err != nil {
+			return err
+		}
+
+		audit.CometID = sourceID
+		return tx.Create(audit).Error
+	})
+}
+
+// AssignObservations в одной транзакции привязывает наблюдения пользователя без кометы
+// к комете, сбрасывает ее флаги расчетов и пишет запись аудита
+func (r *CometsRepository) AssignObservations(ctx context.Context, cometID int, userID int, observationIDs []int, audit *domain.AuditEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Observation{}).
+			Where("id IN ? AND user_id = ? AND comet_id IS NULL AND deleted_at IS NULL", observationIDs, userID).
+			Update("comet_id", cometID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(observationIDs)) {
+			return fmt.Errorf("%w: some observations are not unassigned observations of the user", domain.ErrInvalidInput)
+		}
+
+		if err := resetCalculationFlags(tx, cometID); err != nil {
+			return err
+		}
+
+		return tx.Create(audit).Error
+	})
+}
+
+// resetCalculationFlags помечает орбиту и сближение кометы неактуальными
+func resetCalculationFlags(tx *gorm.DB, cometID int) error {
+	return tx.Model(&domain.Comet{}).
+		Where("id = ?", cometID).
+		Updates(map[string]interface{}{
+			"orbit_actual": false,
+			"close_actual": false,
+		}).Error
+}
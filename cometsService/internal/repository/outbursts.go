@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReplaceOutbursts приводит сохраненные вспышки кометы к результату нового поиска:
+// события для наблюдений, которые больше не выделяются, удаляются, уже известные
+// сохраняют дату обнаружения и отметку об уведомлении. Возвращает новые события.
+func (r *CometsRepository) ReplaceOutbursts(ctx context.Context, cometID int, events []*domain.OutburstEvent) ([]*domain.OutburstEvent, error) {
+	var created []*domain.OutburstEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []*domain.OutburstEvent
+		if err := tx.Where("comet_id = ?", cometID).Find(&existing).Error; err != nil {
+			return err
+		}
+		known := make(map[int]*domain.OutburstEvent, len(existing))
+		for _, e := range existing {
+			known[e.ObservationID] = e
+		}
+
+		keep := make(map[int]bool, len(events))
+		for _, e := range events {
+			keep[e.ObservationID] = true
+			if old, ok := known[e.ObservationID]; ok {
+				e.ID, e.DetectedAt, e.NotifiedAt = old.ID, old.DetectedAt, old.NotifiedAt
+				if err := tx.Save(e).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(e).Error; err != nil {
+				return err
+			}
+			created = append(created, e)
+		}
+
+		var stale []int
+		for _, e := range existing {
+			if !keep[e.ObservationID] {
+				stale = append(stale, e.ID)
+			}
+		}
+		if len(stale) == 0 {
+			return nil
+		}
+		return tx.Delete(&domain.OutburstEvent{}, stale).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *CometsRepository) GetOutburstsByCometID(ctx context.Context, cometID int, userID int) ([]*domain.OutburstEvent, error) {
+	var events []*domain.OutburstEvent
+	err := r.db.WithContext(ctx).
+		Where("comet_id = ? AND user_id = ?", cometID, userID).
+		Order("observed_at ASC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *CometsRepository) MarkOutburstNotified(ctx context.Context, id int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.OutburstEvent{}).
+		Where("id = ?", id).
+		Update("notified_at", at).Error
+}
+
+// UpsertOutburstSubscription создает подписку или меняет адрес существующей
+func (r *CometsRepository) UpsertOutburstSubscription(ctx context.Context, subscription *domain.OutburstSubscription) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "comet_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"webhook_url"}),
+		}).
+		Create(subscription).Error
+}
+
+func (r *CometsRepository) GetOutburstSubscriptions(ctx context.Context, cometID int) ([]*domain.OutburstSubscription, error) {
+	var subscriptions []*domain.OutburstSubscription
+	err := r.db.WithContext(ctx).Where("comet_id = ?", cometID).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *CometsRepository) DeleteOutburstSubscription(ctx context.Context, cometID int, userID int) error {
+	result := r.db.WithContext(ctx).
+		Where("comet_id = ? AND user_id = ?", cometID, userID).
+		Delete(&domain.OutburstSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
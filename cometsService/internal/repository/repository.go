@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
@@ -42,28 +43,149 @@ func (r *CometsRepository) GetCometsByID(ctx context.Context, id int) (*domain.C
 
 func (r *CometsRepository) GetCometsByUserID(ctx context.Context, userID int) ([]*domain.Comet, error) {
 	var comets []*domain.Comet
-	result := r.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&comets)
+	// Сценарии показываются только под своей исходной кометой
+	result := r.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL AND scenario_of IS NULL", userID).Find(&comets)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return comets, nil
 }
 
-func (r *CometsRepository) DeleteComets(ctx context.Context, id int, userID int) error {
-	// Soft delete - устанавливаем deleted_at
-	result := r.db.WithContext(ctx).Model(&domain.Comet{}).
-		Where("id = ? AND user_id = ?", id, userID).
-		Update("deleted_at", time.Now())
+// GetCometByDesignation ищет действующую комету пользователя (не сценарий) по нормализованному обозначению
+func (r *CometsRepository) GetCometByDesignation(ctx context.Context, userID int, designation string) (*domain.Comet, error) {
+	var comet domain.Comet
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND designation = ? AND deleted_at IS NULL AND scenario_of IS NULL", userID, designation).
+		First(&comet)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &comet, nil
+}
+
+// CountCometsByPhotoURL число других комет, в том числе удаленных в корзину, с этой ссылкой на фото
+func (r *CometsRepository) CountCometsByPhotoURL(ctx context.Context, photoURL string, excludeID int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Comet{}).
+		Where("photo_url = ? AND id <> ?", photoURL, excludeID).
+		Count(&count).Error
+	return count, err
+}
+
+// GetStaleComets кометы с устаревшей орбитой, у которых достаточно наблюдений для пересчета.
+// Введенные вручную и взятые из каталога орбиты, как и сценарии, не пересчитываются;
+// учитываются только наблюдения владельца кометы; давно считавшиеся идут первыми.
+// excludeIDs — кометы, отложенные после ошибки: иначе они занимали бы всю выборку.
+func (r *CometsRepository) GetStaleComets(ctx context.Context, minObservations, limit int, excludeIDs []int) ([]*domain.Comet, error) {
+	var comets []*domain.Comet
+	query := r.db.WithContext(ctx)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+	err := query.
+		Where("orbit_actual = ? AND deleted_at IS NULL AND scenario_of IS NULL", false).
+		Where("orbit_source IS NULL OR orbit_source NOT IN ?", []string{domain.OrbitSourceManual, domain.OrbitSourceCatalog}).
+		Where("(SELECT COUNT(*) FROM observations WHERE observations.comet_id = comets.id AND observations.user_id = comets.user_id AND observations.deleted_at IS NULL) >= ?", minObservations).
+		Order("calculated_at ASC").
+		Limit(limit).
+		Find(&comets).Error
+	return comets, err
+}
+
+// GetPendingDynamicsComets кометы, у которых после смены орбиты не рассчитаны 1/a
+func (r *CometsRepository) GetPendingDynamicsComets(ctx context.Context, limit int) ([]*domain.Comet, error) {
+	var comets []*domain.Comet
+	err := r.db.WithContext(ctx).
+		Where("dynamics_pending = ? AND deleted_at IS NULL", true).
+		Order("calculated_at ASC").
+		Limit(limit).
+		Find(&comets).Error
+	return comets, err
+}
 
+// UpdateCometDynamics сохраняет рассчитанные 1/a, только если орбита кометы
+// не сменилась за время расчета (calculated_at тот же)
+func (r *CometsRepository) UpdateCometDynamics(ctx context.Context, comet *domain.Comet) error {
+	return r.db.WithContext(ctx).Model(&domain.Comet{}).
+		Where("id = ? AND calculated_at = ?", comet.ID, comet.CalculatedAt).
+		Updates(map[string]interface{}{
+			"original_inverse_a": comet.OriginalInverseA,
+			"future_inverse_a":   comet.FutureInverseA,
+			"dynamical_class":    comet.DynamicalClass,
+			"dynamics_pending":   comet.DynamicsPending,
+		}).Error
+}
+
+// UpdateCometCalculation сохраняет результаты расчета орбиты и сближения, не трогая
+// остальные поля строки. Запись выполняется, только если комета не удалена и не
+// пересчитывалась с момента чтения (calculated_at равен readCalculatedAt); иначе ErrConflict.
+func (r *CometsRepository) UpdateCometCalculation(ctx context.Context, comet *domain.Comet, readCalculatedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&domain.Comet{}).
+		Where("id = ? AND deleted_at IS NULL AND calculated_at = ?", comet.ID, readCalculatedAt).
+		Updates(map[string]interface{}{
+			"semi_major_axis":        comet.SemiMajorAxis,
+			"eccentricity":           comet.Eccentricity,
+			"raan_deg":               comet.RaanDeg,
+			"inclination_deg":        comet.InclinationDeg,
+			"argument_of_perihelion": comet.ArgumentOfPerihelion,
+			"true_anomaly_deg":       comet.TrueAnomalyDeg,
+			"orbit_epoch":            comet.OrbitEpoch,
+			"orbit_source":           comet.OrbitSource,
+			"orbit_method":           comet.OrbitMethod,
+			"orbit_actual":           comet.OrbitActual,
+			"min_approach_date":      comet.MinApproachDate,
+			"min_approach_distance":  comet.MinApproachDistance,
+			"close_actual":           comet.CloseActual,
+			"calculated_at":          comet.CalculatedAt,
+			"original_inverse_a":     comet.OriginalInverseA,
+			"future_inverse_a":       comet.FutureInverseA,
+			"dynamical_class":        comet.DynamicalClass,
+			"dynamics_pending":       comet.DynamicsPending,
+		})
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: comet %d was deleted or recalculated during the calculation", domain.ErrConflict, comet.ID)
+	}
+	return nil
+}
 
+// DeleteComets помечает комету удаленной (в корзину) вместе с ее наблюдениями и сценариями.
+// Все записи получают одну и ту же отметку deleted_at, по которой их потом восстанавливают.
+// Чужие наблюдения, привязанные к комете, не удаляются и остаются при ней.
+func (r *CometsRepository) DeleteComets(ctx context.Context, id int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return trashComet(tx, id, userID)
+	})
+}
+
+// trashComet каскад DeleteComets внутри транзакции: комета, ее сценарии и наблюдения
+// владельца получают общую отметку deleted_at с точностью, которую хранит база
+func trashComet(tx *gorm.DB, id int, userID int) error {
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	result := tx.Model(&domain.Comet{}).
+		Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).
+		Update("deleted_at", deletedAt)
+	if result.Error != nil {
+		return result.Error
+	}
 	if result.RowsAffected == 0 {
 		return domain.ErrNotFound
 	}
 
-	return nil
+	if err := tx.Model(&domain.Comet{}).
+		Where("scenario_of = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", deletedAt).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&domain.Observation{}).
+		Where("user_id = ? AND deleted_at IS NULL AND (comet_id = ? OR comet_id IN (SELECT id FROM comets WHERE scenario_of = ? AND deleted_at = ?))", userID, id, id, deletedAt).
+		Update("deleted_at", deletedAt).Error
 }
 
 func (r *CometsRepository) UpdateComets(ctx context.Context, comet *domain.Comet) error {
@@ -74,11 +196,27 @@ func (r *CometsRepository) CreateObservation(ctx context.Context, observation *d
 	return r.db.WithContext(ctx).Create(observation).Error
 }
 
+// CreateCometWithObservations в одной транзакции создает комету и привязанные к ней наблюдения
+func (r *CometsRepository) CreateCometWithObservations(ctx context.Context, comet *domain.Comet, observations []*domain.Observation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comet).Error; err != nil {
+			return err
+		}
+		if len(observations) == 0 {
+			return nil
+		}
+		for _, obs := range observations {
+			obs.CometID = &comet.ID
+		}
+		return tx.Create(&observations).Error
+	})
+}
+
 func (r *CometsRepository) GetObservationByID(ctx context.Context, id int) (*domain.Observation, error) {
 	var observation domain.Observation
 	err := r.db.WithContext(ctx).
 		Preload("Comet").
-		Where("id = ?", id).
+		Where("id = ? AND deleted_at IS NULL", id).
 		First(&observation).Error
 
 	if err == gorm.ErrRecordNotFound {
@@ -90,7 +228,7 @@ func (r *CometsRepository) GetObservationByID(ctx context.Context, id int) (*dom
 func (r *CometsRepository) GetUserObservationsByCometID(ctx context.Context, cometID int, userID int) ([]*domain.Observation, error) {
 	var observations []*domain.Observation
 	err := r.db.WithContext(ctx).
-		Where("comet_id = ? AND user_id = ?", cometID, userID).
+		Where("comet_id = ? AND user_id = ? AND deleted_at IS NULL", cometID, userID).
 		Order("observed_at ASC").
 		Find(&observations).Error
 	return observations, err
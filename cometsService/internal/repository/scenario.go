@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CreateScenario в одной транзакции создает комету-сценарий и копирует в нее наблюдения
+// исходной кометы. Копируются только наблюдения владельца сценария: чужие наблюдения,
+// привязанные к комете, в его сценарий не попадают.
+func (r *CometsRepository) CreateScenario(ctx context.Context, sourceID int, scenario *domain.Comet) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(scenario).Error; err != nil {
+			return err
+		}
+
+		var observations []*domain.Observation
+		if err := tx.Where("comet_id = ? AND user_id = ? AND deleted_at IS NULL", sourceID, scenario.UserID).
+			Find(&observations).Error; err != nil {
+			return err
+		}
+		if len(observations) == 0 {
+			return nil
+		}
+
+		for _, obs := range observations {
+			obs.ID = 0
+			obs.CometID = &scenario.ID
+			obs.Comet = nil
+		}
+		return tx.Create(&observations).Error
+	})
+}
+
+func (r *CometsRepository) GetScenariosByCometID(ctx context.Context, cometID int, userID int) ([]*domain.Comet, error) {
+	var comets []*domain.Comet
+	err := r.db.WithContext(ctx).
+		Where("scenario_of = ? AND user_id = ? AND deleted_at IS NULL", cometID, userID).
+		Order("id ASC").
+		Find(&comets).Error
+	return comets, err
+}
+
+// DeleteScenario безвозвратно удаляет сценарий вместе с его наблюдениями
+func (r *CometsRepository) DeleteScenario(ctx context.Context, id int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comet_id = ? AND user_id = ?", id, userID).
+			Delete(&domain.Observation{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND user_id = ? AND is_scenario = ?", id, userID, true).
+			Delete(&domain.Comet{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+}
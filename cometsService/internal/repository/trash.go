@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"gorm.io/gorm"
+)
+
+// Удаленные кометы, которые видны в корзине: сценарии, удаленные вместе с исходной
+// кометой, восстанавливаются и удаляются окончательно только вместе с ней
+const trashedTopLevel = "deleted_at IS NOT NULL AND (scenario_of IS NULL OR NOT EXISTS " +
+	"(SELECT 1 FROM comets parent WHERE parent.id = comets.scenario_of AND parent.deleted_at = comets.deleted_at))"
+
+func (r *CometsRepository) GetDeletedComets(ctx context.Context, userID int) ([]*domain.TrashedComet, error) {
+	var comets []*domain.TrashedComet
+	err := r.db.WithContext(ctx).
+		Model(&domain.Comet{}).
+		Select("comets.*, (SELECT COUNT(*) FROM observations WHERE observations.comet_id = comets.id AND observations.deleted_at = comets.deleted_at) AS observation_count").
+		Where("user_id = ?", userID).
+		Where(trashedTopLevel).
+		Order("deleted_at DESC").
+		Scan(&comets).Error
+	return comets, err
+}
+
+func (r *CometsRepository) GetDeletedComet(ctx context.Context, id int, userID int) (*domain.Comet, error) {
+	var comet domain.Comet
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Where(trashedTopLevel).
+		First(&comet)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &comet, nil
+}
+
+// GetExpiredComets кометы, пролежавшие в корзине дольше срока хранения
+func (r *CometsRepository) GetExpiredComets(ctx context.Context, deletedBefore time.Time, limit int) ([]*domain.Comet, error) {
+	var comets []*domain.Comet
+	err := r.db.WithContext(ctx).
+		Where("deleted_at < ?", deletedBefore).
+		Where(trashedTopLevel).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&comets).Error
+	return comets, err
+}
+
+// RestoreComet снимает отметку удаления с кометы и со всего, что было удалено вместе с ней
+func (r *CometsRepository) RestoreComet(ctx context.Context, comet *domain.Comet) error {
+	if comet.DeletedAt == nil {
+		return nil
+	}
+	deletedAt := *comet.DeletedAt
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Observation{}).
+			Where("deleted_at = ? AND (comet_id = ? OR comet_id IN (SELECT id FROM comets WHERE scenario_of = ? AND deleted_at = ?))", deletedAt, comet.ID, comet.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Comet{}).
+			Where("scenario_of = ? AND deleted_at = ?", comet.ID, deletedAt).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Comet{}).
+			Where("id = ? AND deleted_at = ?", comet.ID, deletedAt).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// PurgeComet безвозвратно удаляет комету из корзины вместе с ее сценариями,
+// удаленными вместе с ней наблюдениями владельца, вспышками и подписками на них.
+// Чужие наблюдения не удаляются, а отвязываются от кометы.
+func (r *CometsRepository) PurgeComet(ctx context.Context, id int, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Model(&domain.Comet{}).
+			Where("user_id = ? AND deleted_at IS NOT NULL AND (id = ? OR scenario_of = ?)", userID, id, id).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return domain.ErrNotFound
+		}
+
+		if err := tx.Where("comet_id IN ? AND user_id = ? AND deleted_at IS NOT NULL", ids, userID).
+			Delete(&domain.Observation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Observation{}).
+			Where("comet_id IN ?", ids).
+			Update("comet_id", nil).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&domain.OutburstEvent{}, &domain.OutburstSubscription{}} {
+			if err := tx.Where("comet_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", ids).Delete(&domain.Comet{}).Error
+	})
+}
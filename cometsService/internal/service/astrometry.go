@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/astrometry"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/coords"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/timescale"
+)
+
+// parseCoordinates разбирает координаты наблюдения из запроса в градусы.
+// Ошибки указывают поле и причину, нулевые значения допустимы.
+func parseCoordinates(ra domain.Coordinate, raUnit string, dec domain.Coordinate, decUnit string) (float64, float64, error) {
+	if !ra.Set {
+		return 0, 0, fmt.Errorf("%w: right_ascension is required", domain.ErrInvalidInput)
+	}
+	if !dec.Set {
+		return 0, 0, fmt.Errorf("%w: declination is required", domain.ErrInvalidInput)
+	}
+
+	unit, err := coords.ParseUnit(raUnit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: ra_unit: %v", domain.ErrInvalidInput, err)
+	}
+	raDeg, err := coords.ParseRA(ra.Raw, unit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: right_ascension: %v", domain.ErrInvalidInput, err)
+	}
+
+	if unit, err = coords.ParseUnit(decUnit); err != nil {
+		return 0, 0, fmt.Errorf("%w: dec_unit: %v", domain.ErrInvalidInput, err)
+	}
+	decDeg, err := coords.ParseDec(dec.Raw, unit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: declination: %v", domain.ErrInvalidInput, err)
+	}
+
+	return raDeg, decDeg, nil
+}
+
+// normalizeFrame приводит координаты наблюдения из указанной системы к ICRF.
+// Исходные значения сохраняются в Reported*, чтобы пересчет можно было проверить.
+func normalizeFrame(observation *domain.Observation, frameName string) error {
+	frame, err := astrometry.ParseFrame(frameName)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	observation.Frame = string(frame)
+	observation.ReportedRightAscension, observation.ReportedDeclination = nil, nil
+
+	if frame == astrometry.FrameICRF {
+		return nil
+	}
+	// Горизонтальные координаты привязаны к пункту и моменту наблюдения, их
+	// пересчет в экваториальные выполняет сервис расчета орбит
+	if observation.IsHorizontal {
+		return fmt.Errorf("%w: frame %s is not applicable to horizontal coordinates", domain.ErrInvalidInput, frame)
+	}
+
+	ra, dec := observation.RightAscension, observation.Declination
+	jdTT := timescale.JulianDate(observation.ObservedAt, timescale.TT)
+	icrfRA, icrfDec, err := astrometry.ToICRF(ra, dec, frame, jdTT)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	observation.RightAscension, observation.Declination = icrfRA, icrfDec
+	observation.ReportedRightAscension, observation.ReportedDeclination = &ra, &dec
+	return nil
+}
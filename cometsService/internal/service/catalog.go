@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const defaultCatalogSearchLimit = 50
+
+func (s *CometsService) SearchCatalog(ctx context.Context, query string, limit int) ([]*domain.CatalogComet, error) {
+	if limit <= 0 {
+		limit = defaultCatalogSearchLimit
+	}
+	return s.cometRepo.SearchCatalogComets(ctx, query, limit)
+}
+
+func (s *CometsService) GetCatalogComet(ctx context.Context, id int) (*domain.CatalogComet, error) {
+	return s.cometRepo.GetCatalogCometByID(ctx, id)
+}
+
+// CreateCometFromCatalog создает комету пользователя с орбитой, взятой из каталога.
+// Перигелийные элементы пересчитываются в кеплеровские на эпоху каталога.
+func (s *CometsService) CreateCometFromCatalog(ctx context.Context, userID, catalogID int, name string) (*domain.Comet, error) {
+	entry, err := s.cometRepo.GetCatalogCometByID(ctx, catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	elements, err := catalogOrbitalElements(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = catalogDisplayName(entry)
+	}
+
+	// Обозначение каталога записывается в комету, если оно корректно по правилам МАС
+	normalized, _ := parseDesignation(entry.Designation)
+	if err := s.checkDesignationFree(ctx, userID, 0, normalized); err != nil {
+		return nil, err
+	}
+
+	comet := &domain.Comet{
+		UserID:               userID,
+		Name:                 name,
+		Designation:          normalized,
+		SemiMajorAxis:        elements.SemiMajorAxis,
+		Eccentricity:         elements.Eccentricity,
+		RaanDeg:              elements.RaanDeg,
+		InclinationDeg:       elements.InclinationDeg,
+		ArgumentOfPerihelion: elements.ArgumentOfPerihelion,
+		TrueAnomalyDeg:       elements.TrueAnomalyDeg,
+		OrbitEpoch:           elements.Epoch,
+		OrbitSource:          domain.OrbitSourceCatalog,
+		OrbitActual:          true,
+		CalculatedAt:         time.Now(),
+	}
+	markDynamicsPending(comet)
+
+	if err := s.cometRepo.CreateComets(ctx, comet); err != nil {
+		return nil, err
+	}
+
+	return comet, nil
+}
+
+// catalogOrbitalElements переводит запись каталога в кеплеровские элементы на эпоху оскуляции
+func catalogOrbitalElements(entry *domain.CatalogComet) (*domain.OrbitalElements, error) {
+	el := catalogElements(entry)
+
+	a, err := el.SemiMajorAxis()
+	if err != nil {
+		return nil, fmt.Errorf("%w: catalog orbit of %s is parabolic", domain.ErrInvalidInput, entry.Designation)
+	}
+
+	epoch := entry.PerihelionTime
+	if entry.Epoch != nil {
+		epoch = *entry.Epoch
+	}
+	nu, _ := el.TrueAnomaly(orbit.JulianDate(epoch))
+
+	elements := &domain.OrbitalElements{
+		SemiMajorAxis:        a,
+		Eccentricity:         entry.Eccentricity,
+		RaanDeg:              entry.RaanDeg,
+		InclinationDeg:       entry.InclinationDeg,
+		ArgumentOfPerihelion: entry.ArgumentOfPerihelion,
+		TrueAnomalyDeg:       nu,
+		Epoch:                &epoch,
+	}
+	if err := elements.Validate(); err != nil {
+		return nil, err
+	}
+
+	return elements, nil
+}
+
+func catalogDisplayName(entry *domain.CatalogComet) string {
+	if entry.Name == "" {
+		return entry.Designation
+	}
+	if strings.Contains(entry.Designation, "/") {
+		return entry.Designation + " (" + entry.Name + ")"
+	}
+	return entry.Designation + "/" + entry.Name
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// ClusterUserComets вычисляет попарные расстояния между орбитами комет пользователя
+// и объединяет в группы кометы, связанные цепочкой пар с расстоянием не выше порога
+// (кластеризация методом одиночной связи).
+func (s *CometsService) ClusterUserComets(ctx context.Context, userID int, req *domain.ClusterCometsRequest) (*domain.CometClusterReport, error) {
+	criterion, err := orbit.ParseCriterion(req.Criterion)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	threshold := orbit.DefaultThreshold[criterion]
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+
+	comets, err := s.cometRepo.GetCometsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Участвуют только кометы с актуальной орбитой
+	var (
+		members  []*domain.Comet
+		elements []orbit.Elements
+	)
+	for _, comet := range comets {
+		if el, ok := cometElements(comet); ok {
+			members = append(members, comet)
+			elements = append(elements, el)
+		}
+	}
+
+	parent := make([]int, len(members))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type pair struct {
+		i, j     int
+		distance *domain.CometPairDistance
+	}
+	var pairs []pair
+	for i := range members {
+		for j := i + 1; j < len(members); j++ {
+			if orbit.Distance(criterion, elements[i], elements[j]) > threshold {
+				continue
+			}
+			parent[find(j)] = find(i)
+			pairs = append(pairs, pair{i, j, &domain.CometPairDistance{
+				CometID:      members[i].ID,
+				OtherCometID: members[j].ID,
+				DSH:          orbit.DSH(elements[i], elements[j]),
+				DDrummond:    orbit.DDrummond(elements[i], elements[j]),
+				DH:           orbit.DH(elements[i], elements[j]),
+			}})
+		}
+	}
+
+	clusters := make(map[int]*domain.CometCluster)
+	var roots []int
+	for _, p := range pairs {
+		root := find(p.i)
+		cluster, ok := clusters[root]
+		if !ok {
+			cluster = &domain.CometCluster{}
+			clusters[root] = cluster
+			roots = append(roots, root)
+		}
+		cluster.Pairs = append(cluster.Pairs, p.distance)
+	}
+	for i, comet := range members {
+		if cluster, ok := clusters[find(i)]; ok {
+			cluster.Comets = append(cluster.Comets, &domain.CometClusterMember{ID: comet.ID, Name: comet.Name})
+		}
+	}
+
+	report := &domain.CometClusterReport{
+		Criterion: string(criterion),
+		Threshold: threshold,
+		Clusters:  make([]*domain.CometCluster, 0, len(roots)),
+	}
+	for _, root := range roots {
+		report.Clusters = append(report.Clusters, clusters[root])
+	}
+
+	// Крупные группы первыми
+	sort.SliceStable(report.Clusters, func(i, j int) bool {
+		return len(report.Clusters[i].Comets) > len(report.Clusters[j].Comets)
+	})
+
+	return report, nil
+}
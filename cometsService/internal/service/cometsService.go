@@ -2,22 +2,40 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/methods"
 	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/database"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/showers"
 )
 
 type CometsService struct {
 	cometRepo         domain.ICometsRepository
 	orbitCalcClient   domain.IOrbitCalculationClient
+	orbitMethods      *methods.Registry
 	fileStorageClient domain.IFileStorageClient
+	outburstNotifier  domain.IOutburstNotifier // nil — уведомления о вспышках не отправляются
+
+	// Пересчеты орбиты одной кометы выполняются по очереди
+	locks     cometLocks
+	scheduler *orbitScheduler // nil — фоновый пересчет не запущен
+
+	// Каталог метеорных потоков загружается при первом обращении
+	showersOnce sync.Once
+	showers     []*showers.Shower
+	showersErr  error
 }
 
 func NewCometsService(
 	cometRepo domain.ICometsRepository,
 	orbitCalcClient domain.IOrbitCalculationClient,
+	orbitMethods *methods.Registry,
+	outburstNotifier domain.IOutburstNotifier,
 ) *CometsService {
 	minio, err := database.NewMinioClient()
 	if err != nil {
@@ -28,34 +46,60 @@ func NewCometsService(
 	return &CometsService{
 		cometRepo:         cometRepo,
 		orbitCalcClient:   orbitCalcClient,
+		orbitMethods:      orbitMethods,
 		fileStorageClient: minio,
+		outburstNotifier:  outburstNotifier,
 	}
 }
 
 // Observation methods
 func (s *CometsService) CreateObservation(ctx context.Context, userID int, req *domain.CreateObservationRequest) (*domain.Observation, error) {
+	observation, err := newObservation(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cometRepo.CreateObservation(ctx, observation); err != nil {
+		return nil, err
+	}
+
+	if req.CometID != nil {
+		if err := s.resetCalculationFlags(ctx, *req.CometID, userID); err != nil {
+			log.Printf("Warning: failed to reset calculation flags: %v", err)
+		}
+	}
+	if observation.TotalMagnitude != nil {
+		s.checkOutbursts(ctx, observation.CometID, userID)
+	}
+
+	return observation, nil
+}
+
+// newObservation строит наблюдение из запроса без сохранения в базу
+func newObservation(userID int, req *domain.CreateObservationRequest) (*domain.Observation, error) {
 	observedAt, err := time.Parse(time.RFC3339, req.ObservedAt)
 	if err != nil {
 		return nil, domain.ErrInvalidInput
 	}
 
+	ra, dec, err := parseCoordinates(req.RightAscension, req.RAUnit, req.Declination, req.DecUnit)
+	if err != nil {
+		return nil, err
+	}
+
 	observation := &domain.Observation{
 		UserID:         userID,
 		CometID:        req.CometID,
-		RightAscension: req.RightAscension,
-		Declination:    req.Declination,
+		RightAscension: ra,
+		Declination:    dec,
 		ObservedAt:     observedAt,
 		IsHorizontal:   req.IsHorizontal,
 	}
-
-	if err := s.cometRepo.CreateObservation(ctx, observation); err != nil {
+	if err := normalizeFrame(observation, req.Frame); err != nil {
 		return nil, err
 	}
-
-	if req.CometID != nil {
-		if err := s.resetCalculationFlags(ctx, *req.CometID, userID); err != nil {
-			log.Printf("Warning: failed to reset calculation flags: %v", err)
-		}
+	if err := applyPhotometry(observation, req.Photometry); err != nil {
+		return nil, err
 	}
 
 	return observation, nil
@@ -65,24 +109,19 @@ func (s *CometsService) GetObservation(ctx context.Context, id int) (*domain.Obs
 	return s.cometRepo.GetObservationByID(ctx, id)
 }
 
-func (s *CometsService) GetUserObservations(ctx context.Context, userID int) ([]*domain.Observation, error) {
-	// Получаем все кометы пользователя
-	comets, err := s.cometRepo.GetCometsByUserID(ctx, userID)
+// GetUserObservations страница наблюдений комет пользователя по фильтру
+func (s *CometsService) GetUserObservations(ctx context.Context, userID int, req *domain.ListObservationsRequest) (*domain.ObservationPage, error) {
+	page, err := pageRequest(req.PageParams, domain.ObservationSortFields)
 	if err != nil {
 		return nil, err
 	}
 
-	// Собираем все наблюдения для всех комет пользователя
-	var allObservations []*domain.Observation
-	for _, comet := range comets {
-		observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, comet.ID, userID)
-		if err != nil {
-			return nil, err
-		}
-		allObservations = append(allObservations, observations...)
+	filter := domain.ObservationFilter{UserID: userID, CometID: req.CometID}
+	if filter.From, filter.To, err = observedRange(req.From, req.To); err != nil {
+		return nil, err
 	}
 
-	return allObservations, nil
+	return s.cometRepo.ListObservations(ctx, filter, page)
 }
 
 func (s *CometsService) GetUserObservationsByCometID(ctx context.Context, cometID int, userID int) ([]*domain.Observation, error) {
@@ -109,16 +148,30 @@ func (s *CometsService) UpdateObservation(ctx context.Context, userID, id int, r
 		return domain.ErrInvalidInput
 	}
 
+	ra, dec, err := parseCoordinates(req.RightAscension, req.RAUnit, req.Declination, req.DecUnit)
+	if err != nil {
+		return err
+	}
+
 	observation := &domain.Observation{
 		ID:             id,
 		UserID:         userID,
 		CometID:        existingObservation.CometID,
-		RightAscension: req.RightAscension,
-		Declination:    req.Declination,
+		RightAscension: ra,
+		Declination:    dec,
 		ObservedAt:     observedAt,
 		IsHorizontal:   existingObservation.IsHorizontal,
 	}
 
+	// Хранимые координаты уже приведены к ICRF; исходная система наблюдения
+	// не подставляется, иначе повторная отправка ответа GET пересчитала бы их еще раз
+	if err := normalizeFrame(observation, req.Frame); err != nil {
+		return err
+	}
+	if err := applyPhotometry(observation, req.Photometry); err != nil {
+		return err
+	}
+
 	err = s.cometRepo.UpdateObservation(ctx, observation)
 	if err != nil {
 		return err
@@ -130,6 +183,9 @@ func (s *CometsService) UpdateObservation(ctx context.Context, userID, id int, r
 			log.Printf("Warning: failed to reset calculation flags: %v", err)
 		}
 	}
+	if existingObservation.TotalMagnitude != nil || observation.TotalMagnitude != nil {
+		s.checkOutbursts(ctx, existingObservation.CometID, userID)
+	}
 
 	return nil
 }
@@ -159,15 +215,26 @@ func (s *CometsService) DeleteObservation(ctx context.Context, id int, userID in
 			log.Printf("Warning: failed to reset calculation flags: %v", err)
 		}
 	}
+	if observation.TotalMagnitude != nil {
+		s.checkOutbursts(ctx, observation.CometID, userID)
+	}
 
 	return nil
 }
 
 // Comet methods
-func (s *CometsService) CreateComet(ctx context.Context, userID int, name string, fileData []byte, fileName string) (*domain.Comet, error) {
+func (s *CometsService) CreateComet(ctx context.Context, userID int, name, designation string, fileData []byte, fileName string) (*domain.Comet, error) {
 	var photoURL string
 	var err error
 
+	normalized, err := resolveDesignation(name, designation)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDesignationFree(ctx, userID, 0, normalized); err != nil {
+		return nil, err
+	}
+
 	// Упрощенная проверка - len() для nil слайсов возвращает 0
 	if len(fileData) > 0 {
 		photoURL, err = s.fileStorageClient.UploadPhoto(ctx, userID, fileData, fileName)
@@ -177,9 +244,10 @@ func (s *CometsService) CreateComet(ctx context.Context, userID int, name string
 	}
 
 	comet := &domain.Comet{
-		UserID:   userID,
-		Name:     name,
-		PhotoURL: photoURL,
+		UserID:      userID,
+		Name:        name,
+		Designation: normalized,
+		PhotoURL:    photoURL,
 	}
 
 	if err := s.cometRepo.CreateComets(ctx, comet); err != nil {
@@ -193,12 +261,156 @@ func (s *CometsService) GetComet(ctx context.Context, id int) (*domain.Comet, er
 	return s.cometRepo.GetCometsByID(ctx, id)
 }
 
-func (s *CometsService) GetUserComets(ctx context.Context, userID int) ([]*domain.Comet, error) {
-	return s.cometRepo.GetCometsByUserID(ctx, userID)
+// GetUserComets страница комет пользователя по фильтру
+func (s *CometsService) GetUserComets(ctx context.Context, userID int, req *domain.ListCometsRequest) (*domain.CometPage, error) {
+	page, err := pageRequest(req.PageParams, domain.CometSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cometRepo.ListComets(ctx, domain.CometFilter{
+		UserID:      userID,
+		Query:       strings.TrimSpace(req.Query),
+		OrbitActual: req.OrbitActual,
+		OrbitClass:  req.OrbitClass,
+	}, page)
+}
+
+// UpdateComet частично обновляет комету: название, обозначение, ссылку на фото и орбитальные элементы.
+// Элементы, заданные вручную, проходят физическую валидацию и помечаются источником manual.
+func (s *CometsService) UpdateComet(ctx context.Context, userID, id int, req *domain.UpdateCometRequest) (*domain.Comet, error) {
+	// Комета сохраняется целиком, поэтому изменение не должно пересечься
+	// с пересчетом орбиты планировщиком
+	release, err := s.locks.lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	comet, err := s.cometRepo.GetCometsByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	// Проверяем права доступа
+	if comet.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidInput)
+		}
+		comet.Name = name
+	}
+
+	if req.Designation != nil {
+		normalized, err := parseDesignation(*req.Designation)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkDesignationFree(ctx, userID, comet.ID, normalized); err != nil {
+			return nil, err
+		}
+		comet.Designation = normalized
+	}
+
+	previous := *comet
+	if req.PhotoURL != nil {
+		photoURL := strings.TrimSpace(*req.PhotoURL)
+		if photoURL != "" && !s.fileStorageClient.IsUserPhoto(photoURL, userID) {
+			return nil, fmt.Errorf("%w: photo_url must point to a photo uploaded by the user", domain.ErrInvalidInput)
+		}
+		comet.PhotoURL = photoURL
+	}
+
+	if req.HasOrbitalElements() {
+		elements := domain.OrbitalElements{
+			SemiMajorAxis:        comet.SemiMajorAxis,
+			Eccentricity:         comet.Eccentricity,
+			RaanDeg:              comet.RaanDeg,
+			InclinationDeg:       comet.InclinationDeg,
+			ArgumentOfPerihelion: comet.ArgumentOfPerihelion,
+			TrueAnomalyDeg:       comet.TrueAnomalyDeg,
+			Epoch:                comet.OrbitEpoch,
+		}
+		if req.SemiMajorAxis != nil {
+			elements.SemiMajorAxis = *req.SemiMajorAxis
+		}
+		if req.Eccentricity != nil {
+			elements.Eccentricity = *req.Eccentricity
+		}
+		if req.RaanDeg != nil {
+			elements.RaanDeg = *req.RaanDeg
+		}
+		if req.InclinationDeg != nil {
+			elements.InclinationDeg = *req.InclinationDeg
+		}
+		if req.ArgumentOfPerihelion != nil {
+			elements.ArgumentOfPerihelion = *req.ArgumentOfPerihelion
+		}
+		if req.TrueAnomalyDeg != nil {
+			elements.TrueAnomalyDeg = *req.TrueAnomalyDeg
+		}
+		if req.OrbitEpoch != nil {
+			epoch, err := time.Parse(time.RFC3339, *req.OrbitEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("%w: orbit_epoch must be in RFC3339 format", domain.ErrInvalidInput)
+			}
+			epoch = epoch.UTC()
+			elements.Epoch = &epoch
+		}
+		if elements.Epoch == nil {
+			return nil, fmt.Errorf("%w: orbit_epoch is required for manual orbital elements", domain.ErrInvalidInput)
+		}
+
+		if err := elements.Validate(); err != nil {
+			return nil, err
+		}
+
+		comet.SemiMajorAxis = elements.SemiMajorAxis
+		comet.Eccentricity = elements.Eccentricity
+		comet.RaanDeg = elements.RaanDeg
+		comet.InclinationDeg = elements.InclinationDeg
+		comet.ArgumentOfPerihelion = elements.ArgumentOfPerihelion
+		comet.TrueAnomalyDeg = elements.TrueAnomalyDeg
+		comet.OrbitEpoch = elements.Epoch
+		comet.OrbitSource = domain.OrbitSourceManual
+		comet.OrbitMethod = ""
+		comet.OrbitActual = true
+		comet.CalculatedAt = calculationTime()
+
+		// Сближение считалось по прежней орбите
+		comet.CloseActual = false
+		comet.MinApproachDate = nil
+		comet.MinApproachDistance = nil
+		markDynamicsPending(comet)
+	}
+
+	if err := s.cometRepo.UpdateComets(ctx, comet); err != nil {
+		return nil, err
+	}
+
+	if comet.PhotoURL != previous.PhotoURL {
+		s.releasePhoto(ctx, &previous)
+	}
+
+	return comet, nil
 }
 
 func (s *CometsService) DeleteComet(ctx context.Context, id int, userID int) error {
-	// Сначала получаем комету, чтобы узнать photoURL и проверить права
+	// Дожидаемся пересчета, иначе его сохранение вернуло бы комету из корзины
+	release, err := s.locks.lock(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Сначала получаем комету, чтобы проверить права
 	comet, err := s.cometRepo.GetCometsByID(ctx, id)
 	if err != nil {
 		return err
@@ -212,20 +424,48 @@ func (s *CometsService) DeleteComet(ctx context.Context, id int, userID int) err
 		return domain.ErrUnauthorized
 	}
 
-	// Удаляем фото из хранилища, если оно есть
-	if comet.PhotoURL != "" {
-		if err := s.fileStorageClient.DeletePhoto(ctx, comet.PhotoURL); err != nil {
-			// Логируем ошибку, но не прерываем удаление кометы
-			log.Printf("Warning: failed to delete photo for comet %d: %v", id, err)
-		}
-	}
-
-	// Soft delete кометы (устанавливаем deleted_at)
+	// Soft delete кометы вместе с наблюдениями; фото остается в хранилище
+	// до окончательного удаления из корзины, чтобы комету можно было восстановить
 	return s.cometRepo.DeleteComets(ctx, id, userID)
 }
 
 // Calculation methods
-func (s *CometsService) CalculateOrbit(ctx context.Context, userID, cometID int) (*domain.CometOrbitResponse, error) {
+// cometOrbitResponse ответ с текущей орбитой кометы
+func cometOrbitResponse(comet *domain.Comet) *domain.CometOrbitResponse {
+	response := &domain.CometOrbitResponse{
+		ID:                   comet.ID,
+		SemiMajorAxis:        &comet.SemiMajorAxis,
+		Eccentricity:         &comet.Eccentricity,
+		RaanDeg:              &comet.RaanDeg,
+		InclinationDeg:       &comet.InclinationDeg,
+		ArgumentOfPerihelion: &comet.ArgumentOfPerihelion,
+		TrueAnomalyDeg:       &comet.TrueAnomalyDeg,
+		OrbitEpoch:           comet.OrbitEpoch,
+		OrbitSource:          comet.OrbitSource,
+		OrbitMethod:          comet.OrbitMethod,
+		OrbitActual:          comet.OrbitActual,
+		OriginalInverseA:     comet.OriginalInverseA,
+		FutureInverseA:       comet.FutureInverseA,
+		DynamicalClass:       comet.DynamicalClass,
+		DynamicsPending:      comet.DynamicsPending,
+	}
+	response.OrbitEpochJD, response.OrbitEpochScale = epochJD(comet.OrbitEpoch)
+	return response
+}
+
+// GetOrbitMethods возвращает доступные методы определения орбиты в порядке перебора
+func (s *CometsService) GetOrbitMethods() []string {
+	return s.orbitMethods.Names()
+}
+
+func (s *CometsService) CalculateOrbit(ctx context.Context, userID, cometID int, method string) (*domain.CometOrbitResponse, error) {
+	// Дожидаемся, если орбиту этой кометы сейчас пересчитывает планировщик
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Проверяем существование кометы и права доступа
 	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
 	if err != nil {
@@ -239,56 +479,69 @@ func (s *CometsService) CalculateOrbit(ctx context.Context, userID, cometID int)
 		return nil, domain.ErrUnauthorized
 	}
 
+	return s.calculateOrbit(ctx, comet, method)
+}
+
+// calculateOrbit определяет и сохраняет орбиту кометы; вызывающий держит блокировку кометы
+func (s *CometsService) calculateOrbit(ctx context.Context, comet *domain.Comet, method string) (*domain.CometOrbitResponse, error) {
 	// Получаем наблюдения для кометы
-	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, cometID, userID)
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, comet.ID, comet.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(observations) < 5 {
+	if len(observations) < minOrbitObservations {
 		return nil, domain.ErrNotEnoughObservations
 	}
 
-	// Вычисляем орбитальные элементы
-	orbitalElements, err := s.orbitCalcClient.CalculateOrbit(ctx, observations)
+	// Вычисляем орбитальные элементы; неподходящий для дуги метод заменяется следующим
+	orbitalElements, usedMethod, err := s.orbitMethods.CalculateOrbit(ctx, method, observations)
 	if err != nil {
 		return nil, err
 	}
 
 	// Обновляем комету с новыми орбитальными элементами
+	readAt := comet.CalculatedAt
 	comet.SemiMajorAxis = orbitalElements.SemiMajorAxis
 	comet.Eccentricity = orbitalElements.Eccentricity
 	comet.RaanDeg = orbitalElements.RaanDeg
 	comet.InclinationDeg = orbitalElements.InclinationDeg
 	comet.ArgumentOfPerihelion = orbitalElements.ArgumentOfPerihelion
 	comet.TrueAnomalyDeg = orbitalElements.TrueAnomalyDeg
+	comet.OrbitEpoch = orbitalElements.Epoch
+	comet.OrbitSource = domain.OrbitSourceComputed
+	comet.OrbitMethod = usedMethod
 	comet.OrbitActual = true // Устанавливаем флаг
-	comet.CalculatedAt = time.Now()
+	comet.CalculatedAt = calculationTime()
 
 	comet.CloseActual = false
 	comet.MinApproachDate = nil
 	comet.MinApproachDistance = nil
-	if err := s.cometRepo.UpdateComets(ctx, comet); err != nil {
+	markDynamicsPending(comet)
+	if err := s.cometRepo.UpdateCometCalculation(ctx, comet, readAt); err != nil {
 		return nil, err
 	}
 
 	// Формируем ответ
-	response := &domain.CometOrbitResponse{
-		ID:                   comet.ID,
-		SemiMajorAxis:        &comet.SemiMajorAxis,
-		Eccentricity:         &comet.Eccentricity,
-		RaanDeg:              &comet.RaanDeg,
-		InclinationDeg:    &comet.InclinationDeg,
-		ArgumentOfPerihelion: &comet.ArgumentOfPerihelion,
-		TrueAnomalyDeg:       &comet.TrueAnomalyDeg,
-		OrbitActual:          comet.OrbitActual,
+	response := cometOrbitResponse(comet)
+
+	// Проверяем, не совпадает ли новая орбита с известной кометой
+	identifications, err := s.identifyComet(ctx, comet, &domain.IdentifyOrbitRequest{})
+	if err != nil {
+		log.Printf("Warning: failed to identify orbit of comet %d: %v", comet.ID, err)
 	}
+	response.Identifications = identifications
 
 	return response, nil
 }
 
 func (s *CometsService) CalculateCloseApproach(ctx context.Context, userID, cometID int) (*domain.CometDistanceResponse, error) {
-	// Проверяем существование кометы и права доступа
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Проверяем существование кометы и права доступа
 	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
 	if err != nil {
@@ -302,17 +555,22 @@ func (s *CometsService) CalculateCloseApproach(ctx context.Context, userID, come
 		return nil, domain.ErrUnauthorized
 	}
 
+	return s.calculateCloseApproach(ctx, comet)
+}
+
+// calculateCloseApproach вычисляет и сохраняет сближение с Землей; вызывающий держит блокировку кометы
+func (s *CometsService) calculateCloseApproach(ctx context.Context, comet *domain.Comet) (*domain.CometDistanceResponse, error) {
 	if !comet.OrbitActual {
 		return nil, domain.ErrOrbitNotCalculated
 	}
 
 	// Получаем наблюдения для кометы
-	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, cometID, userID)
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, comet.ID, comet.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(observations) < 5 {
+	if len(observations) < minOrbitObservations {
 		return nil, domain.ErrNotEnoughObservations
 	}
 
@@ -323,12 +581,13 @@ func (s *CometsService) CalculateCloseApproach(ctx context.Context, userID, come
 	}
 
 	// Обновляем комету с данными о сближении
+	readAt := comet.CalculatedAt
 	comet.MinApproachDate = &closeApproach.Date
 	comet.MinApproachDistance = &closeApproach.Distance
-	comet.CalculatedAt = time.Now()
+	comet.CalculatedAt = calculationTime()
 	comet.CloseActual = true
 
-	if err := s.cometRepo.UpdateComets(ctx, comet); err != nil {
+	if err := s.cometRepo.UpdateCometCalculation(ctx, comet, readAt); err != nil {
 		return nil, err
 	}
 
@@ -346,6 +605,12 @@ func (s *CometsService) CalculateCloseApproach(ctx context.Context, userID, come
 
 // File upload methods
 func (s *CometsService) UploadCometPhoto(ctx context.Context, userID, cometID int, fileData []byte, fileName string) (*domain.Comet, error) {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
 	if err != nil {
 		return nil, err
@@ -363,15 +628,64 @@ func (s *CometsService) UploadCometPhoto(ctx context.Context, userID, cometID in
 		return nil, err
 	}
 
+	previous := *comet
 	comet.PhotoURL = photoURL
 	if err := s.cometRepo.UpdateComets(ctx, comet); err != nil {
 		return nil, err
 	}
 
+	s.releasePhoto(ctx, &previous)
+
 	return comet, nil
 }
 
+// deletePhoto удаляет фото кометы из хранилища. Удаляются только объекты,
+// загруженные владельцем кометы (images/user_<id>/); ошибки пишутся в лог.
+func (s *CometsService) deletePhoto(ctx context.Context, comet *domain.Comet) {
+	if comet.PhotoURL == "" {
+		return
+	}
+	if !strings.Contains(comet.PhotoURL, fmt.Sprintf("/images/user_%d/", comet.UserID)) {
+		log.Printf("Warning: photo of comet %d is not in the owner's folder, keeping it: %s", comet.ID, comet.PhotoURL)
+		return
+	}
+	if err := s.fileStorageClient.DeletePhoto(ctx, comet.PhotoURL); err != nil {
+		log.Printf("Warning: failed to delete photo for comet %d: %v", comet.ID, err)
+	}
+}
+
+// releasePhoto удаляет снятое с кометы фото, если на него не ссылается другая комета,
+// в том числе лежащая в корзине
+func (s *CometsService) releasePhoto(ctx context.Context, comet *domain.Comet) {
+	if comet.PhotoURL == "" {
+		return
+	}
+	count, err := s.cometRepo.CountCometsByPhotoURL(ctx, comet.PhotoURL, comet.ID)
+	if err != nil {
+		log.Printf("Warning: failed to check references to photo of comet %d: %v", comet.ID, err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+	s.deletePhoto(ctx, comet)
+}
+
+// calculationTime момент расчета с точностью, которую хранит база: по calculated_at
+// сохранение результата сверяется с прочитанной строкой
+func calculationTime() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// resetCalculationFlags помечает расчеты кометы устаревшими. Ждет, пока комету
+// освободит пересчет: иначе он сохранил бы орбиту без нового наблюдения как актуальную.
 func (s *CometsService) resetCalculationFlags(ctx context.Context, cometID int, userID int) error {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
 	if err != nil {
 		return err
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/designation"
+)
+
+// LookupComet ищет комету пользователя по обозначению в любой поддерживаемой форме
+func (s *CometsService) LookupComet(ctx context.Context, userID int, query string) (*domain.Comet, error) {
+	normalized, err := parseDesignation(query)
+	if err != nil {
+		return nil, err
+	}
+	if normalized == nil {
+		return nil, fmt.Errorf("%w: designation is required", domain.ErrInvalidInput)
+	}
+
+	comet, err := s.cometRepo.GetCometByDesignation(ctx, userID, *normalized)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+	return comet, nil
+}
+
+// parseDesignation нормализует обозначение; пустая строка означает его отсутствие
+func parseDesignation(s string) (*string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	d, err := designation.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	normalized := d.String()
+	return &normalized, nil
+}
+
+// resolveDesignation берет явно переданное обозначение, а если его нет — пытается
+// извлечь обозначение из названия ("C/2020 F3 (NEOWISE)", "1P/Halley")
+func resolveDesignation(name, explicit string) (*string, error) {
+	if strings.TrimSpace(explicit) != "" {
+		return parseDesignation(explicit)
+	}
+	normalized, err := parseDesignation(name)
+	if err != nil {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// checkDesignationFree проверяет, что обозначение не занято другой кометой пользователя
+func (s *CometsService) checkDesignationFree(ctx context.Context, userID, cometID int, normalized *string) error {
+	if normalized == nil {
+		return nil
+	}
+	existing, err := s.cometRepo.GetCometByDesignation(ctx, userID, *normalized)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != cometID {
+		return fmt.Errorf("%w: comet %d already has designation %s", domain.ErrConflict, existing.ID, *normalized)
+	}
+	return nil
+}
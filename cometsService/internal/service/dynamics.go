@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// Граница 1/a между динамически новыми и старыми кометами (a = 10 000 а.е.), 1/а.е.
+const dynamicallyNewInverseA = 1e-4
+
+// markDynamicsPending сбрасывает 1/a после смены орбиты. Интегрирование с планетами
+// занимает около секунды, поэтому в запросе не выполняется: 1/a считает планировщик
+// или POST /calculations/:comet_id/dynamics. Орбиты, не уходящие за 250 а.е., расчета не ждут.
+func markDynamicsPending(comet *domain.Comet) {
+	comet.OriginalInverseA = nil
+	comet.FutureInverseA = nil
+	comet.DynamicalClass = ""
+
+	elements, ok := cometElements(comet)
+	comet.DynamicsPending = ok && elements.ReachesOriginalDistance()
+}
+
+// updateDynamics вычисляет исходную и будущую барицентрические 1/a по текущей
+// орбите кометы. Если 1/a для орбиты не определены, значения остаются пустыми.
+func updateDynamics(comet *domain.Comet) error {
+	comet.OriginalInverseA = nil
+	comet.FutureInverseA = nil
+	comet.DynamicalClass = ""
+	comet.DynamicsPending = false
+
+	elements, ok := cometElements(comet)
+	if !ok {
+		return nil
+	}
+	result, err := orbit.ComputeOriginalFuture(elements, orbit.JulianDate(cometEpoch(comet)))
+	if errors.Is(err, orbit.ErrNotLongPeriod) || errors.Is(err, orbit.ErrOriginalNotReached) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("original orbit of comet %d: %w", comet.ID, err)
+	}
+
+	original, future := result.Original.InverseA, result.Future.InverseA
+	comet.OriginalInverseA = &original
+	comet.FutureInverseA = &future
+	comet.DynamicalClass = dynamicalClass(original)
+	return nil
+}
+
+// CalculateDynamics вычисляет 1/a кометы по запросу, не дожидаясь планировщика
+func (s *CometsService) CalculateDynamics(ctx context.Context, userID, cometID int) (*domain.CometOrbitResponse, error) {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+	if !comet.OrbitActual {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	if err := s.computeDynamics(ctx, comet); err != nil {
+		return nil, err
+	}
+	return cometOrbitResponse(comet), nil
+}
+
+// computeDynamics вычисляет и сохраняет 1/a. При ошибке интегрирования комета
+// снимается с очереди, чтобы планировщик не повторял заведомо неудачный расчет.
+func (s *CometsService) computeDynamics(ctx context.Context, comet *domain.Comet) error {
+	calcErr := updateDynamics(comet)
+	if err := s.cometRepo.UpdateCometDynamics(ctx, comet); err != nil {
+		return err
+	}
+	return calcErr
+}
+
+// dynamicalClass классифицирует комету по исходной 1/a
+func dynamicalClass(originalInverseA float64) string {
+	switch {
+	case originalInverseA < 0:
+		return domain.DynamicalClassHyperbolic
+	case originalInverseA < dynamicallyNewInverseA:
+		return domain.DynamicalClassNew
+	default:
+		return domain.DynamicalClassOld
+	}
+}
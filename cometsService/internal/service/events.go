@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/photometry"
+)
+
+const (
+	// EventPeakBrightness максимум расчетного блеска; остальные типы событий — orbit.EventKind
+	EventPeakBrightness = "peak_brightness"
+
+	defaultEventsSpan = 365 * 24 * time.Hour
+	maxEventsSpan     = 10 * 366 * 24 * time.Hour
+)
+
+// GetCometEvents события кометы на период по сохраненной орбите
+func (s *CometsService) GetCometEvents(ctx context.Context, userID, cometID int, req *domain.EventsRequest) ([]*domain.CometEvent, error) {
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := storedElements(comet); !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	from, to, err := eventsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.cometEvents(ctx, comet, from, to)
+}
+
+// GetUserEvents события всех комет пользователя с сохраненной орбитой, по дате
+func (s *CometsService) GetUserEvents(ctx context.Context, userID int, req *domain.EventsRequest) ([]*domain.CometEvent, error) {
+	from, to, err := eventsPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	comets, err := s.cometRepo.GetCometsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*domain.CometEvent{}
+	for _, comet := range comets {
+		cometEvents, err := s.cometEvents(ctx, comet, from, to)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, cometEvents...)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events, nil
+}
+
+// CreateCalendarFeed выдает новую секретную ссылку на календарь; прежняя перестает работать
+func (s *CometsService) CreateCalendarFeed(ctx context.Context, userID int) (*domain.CalendarFeed, error) {
+	token := make([]byte, 20)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	feed := &domain.CalendarFeed{
+		UserID:    userID,
+		Token:     hex.EncodeToString(token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.cometRepo.UpsertCalendarFeed(ctx, feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (s *CometsService) DeleteCalendarFeed(ctx context.Context, userID int) error {
+	return s.cometRepo.DeleteCalendarFeed(ctx, userID)
+}
+
+// GetCalendarFeedEvents события пользователя, которому выдан токен календаря
+func (s *CometsService) GetCalendarFeedEvents(ctx context.Context, token string, req *domain.EventsRequest) ([]*domain.CometEvent, error) {
+	if token == "" {
+		return nil, domain.ErrNotFound
+	}
+	feed, err := s.cometRepo.GetCalendarFeedByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.GetUserEvents(ctx, feed.UserID, req)
+}
+
+// cometEvents орбитальные события и максимумы блеска кометы на интервале [from, to].
+// Кометы без орбиты событий не имеют; максимум блеска ищется, если есть оценки блеска.
+func (s *CometsService) cometEvents(ctx context.Context, comet *domain.Comet, from, to time.Time) ([]*domain.CometEvent, error) {
+	elements, ok := storedElements(comet)
+	if !ok {
+		return nil, nil
+	}
+
+	var events []*domain.CometEvent
+	for _, e := range orbit.FindEvents(elements, orbit.JulianDate(from), orbit.JulianDate(to)) {
+		events = append(events, &domain.CometEvent{
+			CometID:       comet.ID,
+			CometName:     comet.Name,
+			Type:          string(e.Kind),
+			Date:          orbit.TimeFromJulianDate(e.JD).Truncate(time.Second),
+			HelioDistance: e.R,
+			GeoDistance:   e.Delta,
+			Elongation:    e.Elongation,
+		})
+	}
+
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, comet.ID, comet.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, points := magnitudeEstimates(observations, elements); len(points) > 0 {
+		if law, err := photometry.FitMagnitudeLaw(points); err == nil {
+			events = append(events, brightnessPeaks(comet, elements, law, from, to)...)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events, nil
+}
+
+// brightnessPeaks суточные минимумы расчетной звездной величины внутри интервала
+func brightnessPeaks(comet *domain.Comet, el orbit.Elements, law photometry.MagnitudeLaw, from, to time.Time) []*domain.CometEvent {
+	predictions := predictMagnitudes(el, law, from, to, 24*time.Hour)
+
+	var peaks []*domain.CometEvent
+	for i := 1; i+1 < len(predictions); i++ {
+		p := predictions[i]
+		if p.Magnitude >= predictions[i-1].Magnitude || p.Magnitude > predictions[i+1].Magnitude {
+			continue
+		}
+		magnitude := p.Magnitude
+		peaks = append(peaks, &domain.CometEvent{
+			CometID:       comet.ID,
+			CometName:     comet.Name,
+			Type:          EventPeakBrightness,
+			Date:          p.Date,
+			HelioDistance: p.HelioDistance,
+			GeoDistance:   p.GeoDistance,
+			Elongation:    orbit.SolarElongation(el, orbit.JulianDate(p.Date)),
+			Magnitude:     &magnitude,
+		})
+	}
+	return peaks
+}
+
+// eventsPeriod разбирает период календаря
+func eventsPeriod(req *domain.EventsRequest) (time.Time, time.Time, error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.From != "" {
+		t, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from must be in RFC3339 format", domain.ErrInvalidInput)
+		}
+		from = t.UTC()
+	}
+	to := from.Add(defaultEventsSpan)
+	if req.To != "" {
+		t, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to must be in RFC3339 format", domain.ErrInvalidInput)
+		}
+		to = t.UTC()
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: to must be after from", domain.ErrInvalidInput)
+	}
+	if to.Sub(from) > maxEventsSpan {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: events period must not exceed 10 years", domain.ErrInvalidInput)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const defaultIdentificationLimit = 5
+
+// IdentifyOrbit сравнивает орбиту кометы с каталогом известных комет
+// и возвращает кандидатов, упорядоченных по выбранному критерию сходства.
+func (s *CometsService) IdentifyOrbit(ctx context.Context, userID, cometID int, req *domain.IdentifyOrbitRequest) ([]*domain.OrbitIdentification, error) {
+	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if comet.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if !comet.OrbitActual {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	return s.identifyComet(ctx, comet, req)
+}
+
+// identifyComet подбирает кандидатов из каталога для уже загруженной кометы
+func (s *CometsService) identifyComet(ctx context.Context, comet *domain.Comet, req *domain.IdentifyOrbitRequest) ([]*domain.OrbitIdentification, error) {
+	criterion, err := orbit.ParseCriterion(req.Criterion)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	threshold := orbit.DefaultThreshold[criterion]
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultIdentificationLimit
+	}
+
+	elements, ok := cometElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	entries, err := s.cometRepo.GetAllCatalogComets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		id       *domain.OrbitIdentification
+		distance float64
+	}
+
+	var candidates []candidate
+	for _, entry := range entries {
+		known := catalogElements(entry)
+		id := &domain.OrbitIdentification{
+			CatalogID:   entry.ID,
+			Designation: entry.Designation,
+			Name:        entry.Name,
+			DSH:         orbit.DSH(elements, known),
+			DDrummond:   orbit.DDrummond(elements, known),
+			DH:          orbit.DH(elements, known),
+		}
+
+		distance := orbit.Distance(criterion, elements, known)
+		if distance <= threshold {
+			candidates = append(candidates, candidate{id: id, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	identifications := make([]*domain.OrbitIdentification, len(candidates))
+	for i, c := range candidates {
+		identifications[i] = c.id
+	}
+	return identifications, nil
+}
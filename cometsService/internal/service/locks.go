@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// cometLocks не дает одновременно менять одну комету пользователю и фоновому
+// планировщику: расчет сохраняет результат поверх прочитанной в начале строки.
+// Нулевое значение готово к работе.
+type cometLocks struct {
+	mu   sync.Mutex
+	busy map[int]chan struct{} // Канал закрывается при освобождении кометы
+}
+
+// lock ждет освобождения кометы и захватывает ее; возвращает функцию освобождения
+func (l *cometLocks) lock(ctx context.Context, cometID int) (func(), error) {
+	for {
+		release, wait := l.acquire(cometID)
+		if release != nil {
+			return release, nil
+		}
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// lockAll захватывает несколько комет в порядке возрастания id, чтобы встречные
+// запросы не ждали друг друга бесконечно; повторяющиеся id захватываются один раз
+func (l *cometLocks) lockAll(ctx context.Context, cometIDs ...int) (func(), error) {
+	ids := uniqueInts(cometIDs)
+	sort.Ints(ids)
+
+	releases := make([]func(), 0, len(ids))
+	releaseAll := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, id := range ids {
+		release, err := l.lock(ctx, id)
+		if err != nil {
+			releaseAll()
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return releaseAll, nil
+}
+
+// tryLock захватывает комету, только если она свободна
+func (l *cometLocks) tryLock(cometID int) (func(), bool) {
+	release, _ := l.acquire(cometID)
+	return release, release != nil
+}
+
+func (l *cometLocks) acquire(cometID int) (func(), <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait, ok := l.busy[cometID]; ok {
+		return nil, wait
+	}
+	if l.busy == nil {
+		l.busy = make(map[int]chan struct{})
+	}
+	done := make(chan struct{})
+	l.busy[cometID] = done
+
+	return func() {
+		l.mu.Lock()
+		delete(l.busy, cometID)
+		l.mu.Unlock()
+		close(done)
+	}, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+// MergeComets объединяет две кометы пользователя, оказавшиеся одним объектом:
+// все наблюдения источника переходят в целевую комету, источник удаляется
+func (s *CometsService) MergeComets(ctx context.Context, userID, targetID int, req *domain.MergeCometRequest) (*domain.Comet, error) {
+	if req.SourceCometID == targetID {
+		return nil, fmt.Errorf("%w: cannot merge a comet into itself", domain.ErrInvalidInput)
+	}
+
+	release, err := s.locks.lockAll(ctx, targetID, req.SourceCometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	target, err := s.getOwnedComet(ctx, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.getOwnedComet(ctx, userID, req.SourceCometID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"target_comet_id": target.ID,
+		"source_comet_id": source.ID,
+		"source_name":     source.Name,
+	})
+	audit := &domain.AuditEntry{
+		UserID:  userID,
+		CometID: target.ID,
+		Action:  domain.AuditActionMerge,
+		Details: string(details),
+	}
+
+	if err := s.cometRepo.MergeComets(ctx, target.ID, source.ID, userID, audit); err != nil {
+		return nil, err
+	}
+
+	return s.cometRepo.GetCometsByID(ctx, target.ID)
+}
+
+// SplitComet выделяет выбранные наблюдения кометы в новую комету
+func (s *CometsService) SplitComet(ctx context.Context, userID, sourceID int, req *domain.SplitCometRequest) (*domain.Comet, error) {
+	release, err := s.locks.lock(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	source, err := s.getOwnedComet(ctx, userID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := uniqueInts(req.ObservationIDs)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = source.Name + " (split)"
+	}
+
+	newComet := &domain.Comet{
+		UserID: userID,
+		Name:   name,
+	}
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"source_comet_id": source.ID,
+		"new_comet_name":  name,
+		"observation_ids": ids,
+	})
+	audit := &domain.AuditEntry{
+		UserID:  userID,
+		Action:  domain.AuditActionSplit,
+		Details: string(details),
+	}
+
+	if err := s.cometRepo.SplitComet(ctx, source.ID, userID, ids, newComet, audit); err != nil {
+		return nil, err
+	}
+
+	return newComet, nil
+}
+
+// getOwnedComet возвращает комету, если она существует и принадлежит пользователю
+func (s *CometsService) getOwnedComet(ctx context.Context, userID, cometID int) (*domain.Comet, error) {
+	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if comet.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return comet, nil
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
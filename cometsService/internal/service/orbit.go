@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/timescale"
+)
+
+// cometElements переводит сохраненную орбиту кометы в перигелийные элементы.
+// Возвращает false, если орбита не рассчитана или элементы недопустимы.
+func cometElements(comet *domain.Comet) (orbit.Elements, bool) {
+	if !comet.OrbitActual {
+		return orbit.Elements{}, false
+	}
+
+	el, err := orbit.FromKeplerian(
+		comet.SemiMajorAxis,
+		comet.Eccentricity,
+		comet.InclinationDeg,
+		comet.RaanDeg,
+		comet.ArgumentOfPerihelion,
+		comet.TrueAnomalyDeg,
+		orbit.JulianDate(cometEpoch(comet)),
+	)
+	if err != nil {
+		return orbit.Elements{}, false
+	}
+	return el, true
+}
+
+// cometEpoch эпоха орбиты кометы; для старых записей без эпохи — время расчета
+func cometEpoch(comet *domain.Comet) time.Time {
+	if comet.OrbitEpoch != nil {
+		return *comet.OrbitEpoch
+	}
+	return comet.CalculatedAt
+}
+
+// epochJD эпоха в юлианских днях вместе с названием шкалы для ответов API
+func epochJD(epoch *time.Time) (*float64, string) {
+	if epoch == nil {
+		return nil, ""
+	}
+	jd := orbit.JulianDate(*epoch)
+	return &jd, string(timescale.TDB)
+}
+
+// keplerianElements переводит перигелийные элементы в кеплеровские на заданную эпоху
+func keplerianElements(el orbit.Elements, epoch time.Time) (*domain.OrbitalElements, error) {
+	a, err := el.SemiMajorAxis()
+	if err != nil {
+		return nil, fmt.Errorf("%w: parabolic orbit has no semi-major axis", domain.ErrInvalidInput)
+	}
+	nu, _ := el.TrueAnomaly(orbit.JulianDate(epoch))
+
+	elements := &domain.OrbitalElements{
+		SemiMajorAxis:        a,
+		Eccentricity:         el.E,
+		RaanDeg:              el.Node,
+		InclinationDeg:       el.I,
+		ArgumentOfPerihelion: el.Peri,
+		TrueAnomalyDeg:       nu,
+		Epoch:                &epoch,
+	}
+	if err := elements.Validate(); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// catalogElements перигелийные элементы записи каталога
+func catalogElements(entry *domain.CatalogComet) orbit.Elements {
+	return orbit.Elements{
+		Q:    entry.PerihelionDistance,
+		E:    entry.Eccentricity,
+		I:    entry.InclinationDeg,
+		Node: entry.RaanDeg,
+		Peri: entry.ArgumentOfPerihelion,
+		Tp:   orbit.JulianDate(entry.PerihelionTime),
+	}
+}
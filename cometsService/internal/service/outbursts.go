@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/netguard"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/photometry"
+)
+
+const (
+	// Порог вспышки по умолчанию: комета ярче закона на 1 зв. вел. (в 2.5 раза)
+	defaultOutburstThreshold = 1.0
+	// Для поиска нужны хотя бы две спокойные оценки и одна подозрительная
+	minOutburstEstimates = 3
+
+	notifyTimeout = 30 * time.Second
+)
+
+// outburstThreshold порог вспышки из OUTBURST_THRESHOLD_MAG или значение по умолчанию
+func outburstThreshold() float64 {
+	if v, err := strconv.ParseFloat(os.Getenv("OUTBURST_THRESHOLD_MAG"), 64); err == nil && v > 0 {
+		return v
+	}
+	return defaultOutburstThreshold
+}
+
+func (s *CometsService) GetOutbursts(ctx context.Context, userID, cometID int) ([]*domain.OutburstEvent, error) {
+	if _, err := s.getOwnedComet(ctx, userID, cometID); err != nil {
+		return nil, err
+	}
+	return s.cometRepo.GetOutburstsByCometID(ctx, cometID, userID)
+}
+
+// DetectOutbursts заново ищет вспышки кометы с заданным порогом и возвращает текущий список
+func (s *CometsService) DetectOutbursts(ctx context.Context, userID, cometID int, req *domain.DetectOutburstsRequest) ([]*domain.OutburstEvent, error) {
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := outburstThreshold()
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+
+	return s.detectOutbursts(ctx, comet, threshold)
+}
+
+// detectOutbursts подбирает закон блеска по спокойным оценкам, сохраняет найденные
+// вспышки и рассылает уведомления о новых
+func (s *CometsService) detectOutbursts(ctx context.Context, comet *domain.Comet, threshold float64) ([]*domain.OutburstEvent, error) {
+	elements, ok := storedElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, comet.ID, comet.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates, points := magnitudeEstimates(observations, elements)
+	if len(points) < minOutburstEstimates {
+		// Ранее найденные вспышки теряют основание, если оценок стало слишком мало
+		if _, err := s.cometRepo.ReplaceOutbursts(ctx, comet.ID, nil); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: outburst detection needs at least %d magnitude estimates", domain.ErrNotEnoughObservations, minOutburstEstimates)
+	}
+
+	law, indices, err := photometry.DetectOutbursts(points, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotEnoughObservations, err)
+	}
+
+	now := time.Now()
+	events := make([]*domain.OutburstEvent, len(indices))
+	for i, idx := range indices {
+		predicted := law.Predict(points[idx].R, points[idx].Delta)
+		events[i] = &domain.OutburstEvent{
+			UserID:             comet.UserID,
+			CometID:            comet.ID,
+			ObservationID:      estimates[idx].ID,
+			ObservedAt:         estimates[idx].ObservedAt,
+			Magnitude:          points[idx].Magnitude,
+			PredictedMagnitude: predicted,
+			Brightening:        predicted - points[idx].Magnitude,
+			Threshold:          threshold,
+			DetectedAt:         now,
+		}
+	}
+
+	created, err := s.cometRepo.ReplaceOutbursts(ctx, comet.ID, events)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyOutbursts(comet, created)
+
+	return events, nil
+}
+
+// checkOutbursts повторяет поиск вспышек после изменения оценок блеска кометы.
+// Ошибки не прерывают работу с наблюдением и только записываются в лог.
+func (s *CometsService) checkOutbursts(ctx context.Context, cometID *int, userID int) {
+	if cometID == nil {
+		return
+	}
+	comet, err := s.getOwnedComet(ctx, userID, *cometID)
+	if err != nil {
+		log.Printf("Warning: outburst check for comet %d: %v", *cometID, err)
+		return
+	}
+
+	_, err = s.detectOutbursts(ctx, comet, outburstThreshold())
+	if err != nil && !errors.Is(err, domain.ErrNotEnoughObservations) && !errors.Is(err, domain.ErrOrbitNotCalculated) {
+		log.Printf("Warning: outburst check for comet %d: %v", *cometID, err)
+	}
+}
+
+// notifyOutbursts в фоне отправляет подписчикам кометы уведомления о новых вспышках
+func (s *CometsService) notifyOutbursts(comet *domain.Comet, events []*domain.OutburstEvent) {
+	if s.outburstNotifier == nil || len(events) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		subscriptions, err := s.cometRepo.GetOutburstSubscriptions(ctx, comet.ID)
+		if err != nil {
+			log.Printf("Warning: failed to load outburst subscriptions for comet %d: %v", comet.ID, err)
+			return
+		}
+		if len(subscriptions) == 0 {
+			return
+		}
+
+		for _, event := range events {
+			delivered := false
+			for _, sub := range subscriptions {
+				if err := s.outburstNotifier.NotifyOutburst(ctx, sub, comet, event); err != nil {
+					log.Printf("Warning: outburst notification to user %d failed: %v", sub.UserID, err)
+					continue
+				}
+				delivered = true
+			}
+			if delivered {
+				if err := s.cometRepo.MarkOutburstNotified(ctx, event.ID, time.Now()); err != nil {
+					log.Printf("Warning: failed to mark outburst %d as notified: %v", event.ID, err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *CometsService) SubscribeOutbursts(ctx context.Context, userID, cometID int, req *domain.OutburstSubscriptionRequest) (*domain.OutburstSubscription, error) {
+	if _, err := s.getOwnedComet(ctx, userID, cometID); err != nil {
+		return nil, err
+	}
+
+	// Сервер сам отправляет запросы на этот адрес, поэтому внутренние узлы запрещены;
+	// при отправке та же проверка повторяется в dialer
+	webhook := strings.TrimSpace(req.WebhookURL)
+	if err := netguard.FromEnv().CheckURL(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("%w: webhook_url: %v", domain.ErrInvalidInput, err)
+	}
+
+	subscription := &domain.OutburstSubscription{
+		UserID:     userID,
+		CometID:    cometID,
+		WebhookURL: webhook,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.cometRepo.UpsertOutburstSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *CometsService) UnsubscribeOutbursts(ctx context.Context, userID, cometID int) error {
+	if _, err := s.getOwnedComet(ctx, userID, cometID); err != nil {
+		return err
+	}
+	return s.cometRepo.DeleteOutburstSubscription(ctx, cometID, userID)
+}
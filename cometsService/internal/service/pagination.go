@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+// pageRequest проверяет параметры страницы; первое из fields — сортировка по умолчанию
+func pageRequest(params domain.PageParams, fields []string) (domain.PageRequest, error) {
+	page := domain.PageRequest{
+		Limit: params.Limit,
+		Sort:  params.Sort,
+		Desc:  params.Order == "desc",
+	}
+
+	if page.Limit == 0 {
+		page.Limit = domain.DefaultPageLimit
+	}
+	if page.Limit > domain.MaxPageLimit {
+		return domain.PageRequest{}, fmt.Errorf("%w: limit must not exceed %d", domain.ErrInvalidInput, domain.MaxPageLimit)
+	}
+
+	if page.Sort == "" {
+		page.Sort = fields[0]
+	}
+	if !slices.Contains(fields, page.Sort) {
+		return domain.PageRequest{}, fmt.Errorf("%w: sort must be one of %s", domain.ErrInvalidInput, strings.Join(fields, ", "))
+	}
+
+	if params.Cursor != "" {
+		cursor, err := domain.DecodePageCursor(params.Cursor)
+		if err != nil {
+			return domain.PageRequest{}, err
+		}
+		page.Cursor = cursor
+	}
+	return page, nil
+}
+
+// observedRange разбирает необязательные границы from/to фильтра наблюдений
+func observedRange(from, to string) (*time.Time, *time.Time, error) {
+	bounds := [2]*time.Time{}
+	for i, bound := range []struct{ name, value string }{{"from", from}, {"to", to}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s must be in RFC3339 format", domain.ErrInvalidInput, bound.name)
+		}
+		t = t.UTC()
+		bounds[i] = &t
+	}
+	return bounds[0], bounds[1], nil
+}
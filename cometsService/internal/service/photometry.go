@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/photometry"
+)
+
+const (
+	defaultLightCurveSpan = 180 * 24 * time.Hour
+	defaultLightCurveStep = 5.0 // сутки
+	maxLightCurvePoints   = 2000
+	// Пределы шага: меньший обнуляется при переводе в time.Duration, больший переполняет его
+	minLightCurveStep = time.Minute
+	maxLightCurveStep = 100 * 365.25 // сутки
+
+	// Допустимый диапазон звездных величин комет
+	minMagnitude, maxMagnitude = -30.0, 30.0
+)
+
+// Полосы, в которых принимаются оценки блеска
+var magnitudeBands = map[string]string{
+	"V": "V", "R": "R", "B": "B", "I": "I", "G": "G",
+	"VISUAL": "visual", "VIS": "visual",
+}
+
+// storedElements последняя сохраненная орбита кометы. Для фотометрии и календаря
+// событий ее достаточно, даже если после расчета добавились новые наблюдения.
+func storedElements(comet *domain.Comet) (orbit.Elements, bool) {
+	if comet.SemiMajorAxis == 0 {
+		return orbit.Elements{}, false
+	}
+	stored := *comet
+	stored.OrbitActual = true
+	return cometElements(&stored)
+}
+
+// magnitudeEstimates отбирает наблюдения с оценкой полного блеска и дополняет их
+// расстояниями r и Δ по орбите
+func magnitudeEstimates(observations []*domain.Observation, elements orbit.Elements) ([]*domain.Observation, []photometry.MagnitudePoint) {
+	var estimates []*domain.Observation
+	var points []photometry.MagnitudePoint
+	for _, obs := range observations {
+		if obs.TotalMagnitude == nil {
+			continue
+		}
+		eph := elements.EphemerisAt(orbit.JulianDate(obs.ObservedAt))
+		estimates = append(estimates, obs)
+		points = append(points, photometry.MagnitudePoint{Magnitude: *obs.TotalMagnitude, R: eph.R, Delta: eph.Delta})
+	}
+	return estimates, points
+}
+
+// applyPhotometry проверяет и переносит оценки блеска из запроса в наблюдение
+func applyPhotometry(observation *domain.Observation, p domain.Photometry) error {
+	for _, m := range []struct {
+		name  string
+		value *float64
+	}{
+		{"total_magnitude", p.TotalMagnitude},
+		{"nuclear_magnitude", p.NuclearMagnitude},
+	} {
+		if m.value != nil && (math.IsNaN(*m.value) || *m.value < minMagnitude || *m.value > maxMagnitude) {
+			return fmt.Errorf("%w: %s %g is outside %g..%g", domain.ErrInvalidInput, m.name, *m.value, minMagnitude, maxMagnitude)
+		}
+	}
+
+	band := strings.TrimSpace(p.MagnitudeBand)
+	if band != "" {
+		normalized, ok := magnitudeBands[strings.ToUpper(band)]
+		if !ok {
+			return fmt.Errorf("%w: unknown magnitude_band %q (expected V, R, B, I, G or visual)", domain.ErrInvalidInput, band)
+		}
+		band = normalized
+	}
+
+	filter := strings.TrimSpace(p.Filter)
+	if filter != "" {
+		normalized, err := photometry.NormalizeFilter(filter)
+		if err != nil {
+			return fmt.Errorf("%w: unknown filter %q (expected B, V, Rc, Ic, g', r' or i')", domain.ErrInvalidInput, filter)
+		}
+		filter = normalized
+	}
+	if p.ApertureRadiusArcsec != nil && p.TotalMagnitude == nil {
+		return fmt.Errorf("%w: aperture_radius_arcsec requires total_magnitude", domain.ErrInvalidInput)
+	}
+
+	observation.TotalMagnitude = p.TotalMagnitude
+	observation.NuclearMagnitude = p.NuclearMagnitude
+	observation.MagnitudeBand = band
+	observation.ApertureCm = p.ApertureCm
+	observation.ApertureRadiusArcsec = p.ApertureRadiusArcsec
+	observation.Filter = filter
+	return nil
+}
+
+// GetLightCurve подбирает закон блеска по оценкам полной звездной величины,
+// беря расстояния r и Δ из сохраненной орбиты, и строит прогноз блеска.
+func (s *CometsService) GetLightCurve(ctx context.Context, userID, cometID int, req *domain.LightCurveRequest) (*domain.LightCurveResponse, error) {
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+	elements, ok := storedElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	from, to, step, err := lightCurvePeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, cometID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates, points := magnitudeEstimates(observations, elements)
+	if len(points) == 0 {
+		return nil, fmt.Errorf("%w: comet has no total magnitude estimates", domain.ErrNotEnoughObservations)
+	}
+
+	law, err := photometry.FitMagnitudeLaw(points)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotEnoughObservations, err)
+	}
+
+	response := &domain.LightCurveResponse{
+		CometID:      cometID,
+		M1:           law.M1,
+		K1:           law.K1,
+		SigmaM1:      law.SigmaM1,
+		SigmaK1:      law.SigmaK1,
+		K1Fixed:      law.FixedK1,
+		RMS:          law.RMS,
+		Observations: make([]*domain.LightCurvePoint, len(points)),
+	}
+	for i, p := range points {
+		response.Observations[i] = &domain.LightCurvePoint{
+			ObservationID: estimates[i].ID,
+			ObservedAt:    estimates[i].ObservedAt,
+			Magnitude:     p.Magnitude,
+			Band:          estimates[i].MagnitudeBand,
+			HelioDistance: p.R,
+			GeoDistance:   p.Delta,
+			Residual:      law.Residual(p),
+		}
+	}
+	response.Predictions = predictMagnitudes(elements, law, from, to, step)
+
+	return response, nil
+}
+
+// lightCurvePeriod разбирает период прогноза и шаг
+func lightCurvePeriod(req *domain.LightCurveRequest) (time.Time, time.Time, time.Duration, error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.From != "" {
+		t, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: from must be in RFC3339 format", domain.ErrInvalidInput)
+		}
+		from = t.UTC()
+	}
+	to := from.Add(defaultLightCurveSpan)
+	if req.To != "" {
+		t, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: to must be in RFC3339 format", domain.ErrInvalidInput)
+		}
+		to = t.UTC()
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: to must be after from", domain.ErrInvalidInput)
+	}
+
+	stepDays := req.StepDays
+	if stepDays <= 0 {
+		stepDays = defaultLightCurveStep
+	}
+	if stepDays < minLightCurveStep.Hours()/24 || stepDays > maxLightCurveStep {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: step_days must be between 1 minute and %g days", domain.ErrInvalidInput, maxLightCurveStep)
+	}
+	step := time.Duration(stepDays * float64(24*time.Hour))
+	if to.Sub(from)/step >= maxLightCurvePoints {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%w: light curve period exceeds %d points", domain.ErrInvalidInput, maxLightCurvePoints)
+	}
+	return from, to, step, nil
+}
+
+func predictMagnitudes(el orbit.Elements, law photometry.MagnitudeLaw, from, to time.Time, step time.Duration) []*domain.MagnitudePrediction {
+	var predictions []*domain.MagnitudePrediction
+	for t := from; !t.After(to); t = t.Add(step) {
+		eph := el.EphemerisAt(orbit.JulianDate(t))
+		predictions = append(predictions, &domain.MagnitudePrediction{
+			Date:          t,
+			Magnitude:     law.Predict(eph.R, eph.Delta),
+			HelioDistance: eph.R,
+			GeoDistance:   eph.Delta,
+		})
+	}
+	return predictions
+}
+
+// GetAfRho рассчитывает Afρ по оценкам блеска, измеренным в диафрагме известного
+// радиуса. Расстояния r, Δ и фазовый угол берутся из сохраненной орбиты.
+func (s *CometsService) GetAfRho(ctx context.Context, userID, cometID int, req *domain.AfRhoRequest) (*domain.AfRhoResponse, error) {
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+	elements, ok := storedElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	beta := photometry.DefaultPhaseCoefficient
+	if req.PhaseCoefficient != nil {
+		beta = *req.PhaseCoefficient
+	}
+
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, cometID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.AfRhoResponse{CometID: cometID, PhaseCoefficient: beta, Points: []*domain.AfRhoPoint{}}
+	for _, obs := range observations {
+		filter, sunMag, ok := afRhoFilter(obs)
+		if !ok || obs.TotalMagnitude == nil || obs.ApertureRadiusArcsec == nil {
+			continue
+		}
+		eph := elements.EphemerisAt(orbit.JulianDate(obs.ObservedAt))
+		point := photometry.AfRhoPoint{
+			Magnitude:      *obs.TotalMagnitude,
+			SolarMagnitude: sunMag,
+			ApertureArcsec: *obs.ApertureRadiusArcsec,
+			R:              eph.R,
+			Delta:          eph.Delta,
+			Phase:          eph.Phase,
+		}
+		afrho := photometry.ComputeAfRho(point, beta)
+		response.Points = append(response.Points, &domain.AfRhoPoint{
+			ObservationID:  obs.ID,
+			ObservedAt:     obs.ObservedAt,
+			Magnitude:      point.Magnitude,
+			Filter:         filter,
+			ApertureArcsec: point.ApertureArcsec,
+			RhoKm:          afrho.RhoKm,
+			HelioDistance:  eph.R,
+			GeoDistance:    eph.Delta,
+			PhaseAngle:     eph.Phase,
+			AfRho:          afrho.AfRho,
+			AfRho0:         afrho.AfRho0,
+		})
+	}
+	if len(response.Points) == 0 {
+		return nil, fmt.Errorf("%w: comet has no aperture photometry with a known filter", domain.ErrNotEnoughObservations)
+	}
+	return response, nil
+}
+
+// afRhoFilter фильтр наблюдения и блеск Солнца в нем. Без явного фильтра
+// используется полоса оценки блеска, если это широкополосный фильтр Джонсона–Казинса.
+func afRhoFilter(obs *domain.Observation) (string, float64, bool) {
+	filter := obs.Filter
+	if filter == "" {
+		switch obs.MagnitudeBand {
+		case "B", "V", "R", "I":
+			filter = obs.MagnitudeBand
+		default:
+			return "", 0, false
+		}
+	}
+	name, err := photometry.NormalizeFilter(filter)
+	if err != nil {
+		return "", 0, false
+	}
+	sunMag, _ := photometry.SolarMagnitude(name)
+	return name, sunMag, true
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	defaultRefineIterations = 25
+	// refinedMethodSuffix добавляется к методу орбиты после уточнения наименьшими квадратами
+	refinedMethodSuffix = "+lsq"
+)
+
+// RefineOrbit уточняет сохраненную орбиту кометы по всем ее экваториальным наблюдениям
+// методом наименьших квадратов. Результат сохраняется, только если итерации сошлись
+// и невязка не выросла; в остальных случаях отчет возвращается без изменения орбиты.
+// Введенная вручную или взятая из каталога орбита заменяется только с req.Force.
+func (s *CometsService) RefineOrbit(ctx context.Context, userID, cometID int, req *domain.RefineOrbitRequest) (*domain.OrbitRefinementResponse, error) {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	comet, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+
+	initial, ok := cometElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+	if (comet.OrbitSource == domain.OrbitSourceManual || comet.OrbitSource == domain.OrbitSourceCatalog) && !req.Force {
+		return nil, fmt.Errorf("%w: comet %d has a %s orbit, pass force=true to replace it with a refined one",
+			domain.ErrConflict, cometID, comet.OrbitSource)
+	}
+
+	observations, err := s.cometRepo.GetUserObservationsByCometID(ctx, cometID, userID)
+	if err != nil {
+		return nil, err
+	}
+	angles := angleObservations(observations)
+	if len(angles) < minOrbitObservations {
+		return nil, domain.ErrNotEnoughObservations
+	}
+
+	maxIterations := req.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultRefineIterations
+	}
+
+	epoch := cometEpoch(comet)
+	correction, err := orbit.DifferentialCorrection(initial, orbit.JulianDate(epoch), angles, maxIterations)
+	if errors.Is(err, orbit.ErrTooFewObservations) {
+		return nil, domain.ErrNotEnoughObservations
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.OrbitRefinementResponse{
+		Converged:        correction.Converged,
+		Iterations:       correction.Iterations,
+		ObservationsUsed: len(angles),
+		InitialRMS:       correction.InitialRMS,
+		RMS:              correction.RMS,
+	}
+
+	if covariance, err := correction.KeplerianCovariance(); err == nil {
+		response.Covariance = make([][]float64, len(covariance))
+		for i := range covariance {
+			response.Covariance[i] = covariance[i][:]
+		}
+		response.CovarianceElements = orbit.KeplerianNames[:]
+		response.Uncertainty = &domain.OrbitUncertainty{
+			SemiMajorAxis:        math.Sqrt(covariance[0][0]),
+			Eccentricity:         math.Sqrt(covariance[1][1]),
+			InclinationDeg:       math.Sqrt(covariance[2][2]),
+			RaanDeg:              math.Sqrt(covariance[3][3]),
+			ArgumentOfPerihelion: math.Sqrt(covariance[4][4]),
+			TrueAnomalyDeg:       math.Sqrt(covariance[5][5]),
+		}
+	}
+
+	elements, err := keplerianElements(correction.Elements, epoch)
+	if err == nil && correction.Converged && correction.RMS <= correction.InitialRMS {
+		comet.SemiMajorAxis = elements.SemiMajorAxis
+		comet.Eccentricity = elements.Eccentricity
+		comet.RaanDeg = elements.RaanDeg
+		comet.InclinationDeg = elements.InclinationDeg
+		comet.ArgumentOfPerihelion = elements.ArgumentOfPerihelion
+		comet.TrueAnomalyDeg = elements.TrueAnomalyDeg
+		comet.OrbitEpoch = elements.Epoch
+		// Имя метода строится по исходному источнику, поэтому источник меняется после него
+		comet.OrbitMethod = refinedMethod(comet.OrbitSource, comet.OrbitMethod)
+		comet.OrbitSource = domain.OrbitSourceComputed
+		comet.CalculatedAt = calculationTime()
+
+		comet.CloseActual = false
+		comet.MinApproachDate = nil
+		comet.MinApproachDistance = nil
+		markDynamicsPending(comet)
+		if err := s.cometRepo.UpdateComets(ctx, comet); err != nil {
+			return nil, err
+		}
+		response.Applied = true
+	}
+
+	response.Orbit = cometOrbitResponse(comet)
+	return response, nil
+}
+
+// angleObservations экваториальные наблюдения в виде, пригодном для расчетов орбиты.
+// Наблюдатель считается находящимся в центре Земли.
+func angleObservations(observations []*domain.Observation) []orbit.AngleObservation {
+	angles := make([]orbit.AngleObservation, 0, len(observations))
+	for _, obs := range observations {
+		if obs.IsHorizontal {
+			continue
+		}
+		angles = append(angles, orbit.AngleObservation{
+			JD:  orbit.JulianDate(obs.ObservedAt),
+			RA:  obs.RightAscension,
+			Dec: obs.Declination,
+		})
+	}
+	return angles
+}
+
+// refinedMethod имя метода уточненной орбиты. У введенных вручную и каталожных орбит
+// метода нет, поэтому в имени указывается источник исходных элементов.
+func refinedMethod(source, method string) string {
+	if method == "" {
+		method = source
+	}
+	if method == "" {
+		return strings.TrimPrefix(refinedMethodSuffix, "+")
+	}
+	if strings.HasSuffix(method, refinedMethodSuffix) {
+		return method
+	}
+	return method + refinedMethodSuffix
+}
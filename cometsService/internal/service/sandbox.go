@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// minOrbitObservations минимальное число наблюдений для расчета орбиты
+const minOrbitObservations = 5
+
+// SandboxOrbit рассчитывает орбиту по наблюдениям из запроса, не обращаясь к репозиторию.
+// Позволяет проверить разные подборки и исправленные значения до их сохранения.
+func (s *CometsService) SandboxOrbit(ctx context.Context, userID int, req *domain.SandboxOrbitRequest) (*domain.SandboxOrbitResponse, error) {
+	observations := make([]*domain.Observation, len(req.Observations))
+	for i := range req.Observations {
+		observation, err := newObservation(userID, &req.Observations[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: observation %d", err, i)
+		}
+		observations[i] = observation
+	}
+
+	if len(observations) < minOrbitObservations {
+		return nil, domain.ErrNotEnoughObservations
+	}
+
+	elements, method, err := s.orbitMethods.CalculateOrbit(ctx, req.Method, observations)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.SandboxOrbitResponse{
+		SemiMajorAxis:        elements.SemiMajorAxis,
+		Eccentricity:         elements.Eccentricity,
+		RaanDeg:              elements.RaanDeg,
+		InclinationDeg:       elements.InclinationDeg,
+		ArgumentOfPerihelion: elements.ArgumentOfPerihelion,
+		TrueAnomalyDeg:       elements.TrueAnomalyDeg,
+		OrbitEpoch:           elements.Epoch,
+		OrbitMethod:          method,
+		Residuals:            []*domain.ObservationResidual{},
+	}
+	response.OrbitEpochJD, response.OrbitEpochScale = epochJD(elements.Epoch)
+
+	// Невязки считаются только при известной эпохе элементов
+	if elements.Epoch != nil {
+		response.Residuals, response.RMS, response.AccuracyFloor = computeResiduals(elements, observations)
+	}
+
+	if req.Trajectory != nil {
+		startTime, err := time.Parse(time.RFC3339, req.Trajectory.StartTime)
+		if err != nil {
+			return nil, domain.ErrInvalidInput
+		}
+		endTime, err := time.Parse(time.RFC3339, req.Trajectory.EndTime)
+		if err != nil {
+			return nil, domain.ErrInvalidInput
+		}
+		if !endTime.After(startTime) {
+			return nil, domain.ErrInvalidInput
+		}
+
+		trajectory, err := s.orbitCalcClient.GetTrajectory(ctx, observations, startTime, endTime, req.Trajectory.NumPoints)
+		if err != nil {
+			return nil, err
+		}
+		response.Trajectory = trajectory
+	}
+
+	return response, nil
+}
+
+// computeResiduals вычисляет невязки O-C экваториальных наблюдений, их RMS и нижнюю
+// границу точности модели в угловых секундах. Граница — ошибка положения Земли,
+// видимая с наименьшего геоцентрического расстояния среди наблюдений.
+// Наблюдения в горизонтальных координатах пропускаются.
+func computeResiduals(elements *domain.OrbitalElements, observations []*domain.Observation) ([]*domain.ObservationResidual, *float64, *float64) {
+	el, err := orbit.FromKeplerian(
+		elements.SemiMajorAxis,
+		elements.Eccentricity,
+		elements.InclinationDeg,
+		elements.RaanDeg,
+		elements.ArgumentOfPerihelion,
+		elements.TrueAnomalyDeg,
+		orbit.JulianDate(*elements.Epoch),
+	)
+	if err != nil {
+		return []*domain.ObservationResidual{}, nil, nil
+	}
+
+	residuals := []*domain.ObservationResidual{}
+	var sum float64
+	minDelta := math.Inf(1)
+	for i, obs := range observations {
+		if obs.IsHorizontal {
+			continue
+		}
+
+		eph := el.EphemerisAt(orbit.JulianDate(obs.ObservedAt))
+		minDelta = math.Min(minDelta, eph.Delta)
+		dRA := math.Remainder(obs.RightAscension-eph.RA, 360) * math.Cos(obs.Declination*math.Pi/180) * 3600
+		dDec := (obs.Declination - eph.Dec) * 3600
+		total := math.Hypot(dRA, dDec)
+
+		residuals = append(residuals, &domain.ObservationResidual{
+			Index:      i,
+			ObservedAt: obs.ObservedAt,
+			DeltaRA:    dRA,
+			DeltaDec:   dDec,
+			Total:      total,
+		})
+		sum += total * total
+	}
+
+	if len(residuals) == 0 {
+		return residuals, nil, nil
+	}
+	rms := math.Sqrt(sum / float64(len(residuals)))
+	floor := orbit.EarthPositionError / minDelta / math.Pi * 180 * 3600
+	return residuals, &rms, &floor
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+// CreateScenario копирует комету и все ее наблюдения в новую комету-сценарий.
+// Изменения наблюдений сценария и пересчет его орбиты не затрагивают оригинал.
+func (s *CometsService) CreateScenario(ctx context.Context, userID, cometID int, req *domain.CreateScenarioRequest) (*domain.Comet, error) {
+	source, err := s.getOwnedComet(ctx, userID, cometID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = source.Name + " (scenario)"
+	}
+
+	// Фото не копируется: оно удаляется из хранилища вместе с исходной кометой
+	scenario := *source
+	scenario.ID = 0
+	scenario.Name = name
+	scenario.PhotoURL = ""
+	scenario.IsScenario = true
+	scenario.ScenarioOf = &source.ID
+	scenario.DeletedAt = nil
+
+	if err := s.cometRepo.CreateScenario(ctx, source.ID, &scenario); err != nil {
+		return nil, err
+	}
+
+	return &scenario, nil
+}
+
+func (s *CometsService) GetScenarios(ctx context.Context, userID, cometID int) ([]*domain.Comet, error) {
+	if _, err := s.getOwnedComet(ctx, userID, cometID); err != nil {
+		return nil, err
+	}
+	return s.cometRepo.GetScenariosByCometID(ctx, cometID, userID)
+}
+
+// DiscardScenario удаляет сценарий кометы вместе с его наблюдениями
+func (s *CometsService) DiscardScenario(ctx context.Context, userID, cometID, scenarioID int) error {
+	release, err := s.locks.lock(ctx, scenarioID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	scenario, err := s.getOwnedComet(ctx, userID, scenarioID)
+	if err != nil {
+		return err
+	}
+
+	if !scenario.IsScenario || scenario.ScenarioOf == nil || *scenario.ScenarioOf != cometID {
+		return domain.ErrNotFound
+	}
+
+	return s.cometRepo.DeleteScenario(ctx, scenarioID, userID)
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+// Параметры фонового пересчета по умолчанию
+const (
+	defaultSchedulerInterval   = 10 * time.Minute
+	defaultSchedulerBatch      = 20
+	defaultSchedulerRate       = 30 // Пересчетов в минуту
+	defaultSchedulerRetryAfter = 6 * time.Hour
+	schedulerCometTimeout      = 2 * time.Minute
+)
+
+// OrbitSchedulerConfig настройки фонового пересчета устаревших орбит
+type OrbitSchedulerConfig struct {
+	Enabled       bool
+	Interval      time.Duration // Пауза между проходами
+	BatchSize     int           // Комет за один проход
+	RatePerMinute int           // Не больше стольких пересчетов в минуту
+	Method        string        // Метод определения орбиты; пусто — порядок реестра
+	RetryAfter    time.Duration // Через сколько повторять комету после ошибки
+}
+
+// OrbitSchedulerConfigFromEnv читает настройки из ORBIT_SCHEDULER_*; по умолчанию планировщик выключен
+func OrbitSchedulerConfigFromEnv() OrbitSchedulerConfig {
+	cfg := OrbitSchedulerConfig{
+		Enabled:       os.Getenv("ORBIT_SCHEDULER_ENABLED") == "true",
+		Interval:      defaultSchedulerInterval,
+		BatchSize:     defaultSchedulerBatch,
+		RatePerMinute: defaultSchedulerRate,
+		Method:        os.Getenv("ORBIT_SCHEDULER_METHOD"),
+		RetryAfter:    defaultSchedulerRetryAfter,
+	}
+	if v, err := time.ParseDuration(os.Getenv("ORBIT_SCHEDULER_INTERVAL")); err == nil && v > 0 {
+		cfg.Interval = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("ORBIT_SCHEDULER_BATCH")); err == nil && v > 0 {
+		cfg.BatchSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("ORBIT_SCHEDULER_RATE")); err == nil && v > 0 {
+		cfg.RatePerMinute = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("ORBIT_SCHEDULER_RETRY_AFTER")); err == nil && v > 0 {
+		cfg.RetryAfter = v
+	}
+	return cfg
+}
+
+// orbitScheduler периодически пересчитывает орбиты и сближения комет,
+// у которых после изменения наблюдений сброшен флаг OrbitActual, а затем
+// вычисляет 1/a для комет, орбита которых сменилась (DynamicsPending)
+type orbitScheduler struct {
+	service *CometsService
+	cfg     OrbitSchedulerConfig
+
+	mu      sync.Mutex
+	status  domain.OrbitSchedulerStatus
+	retryAt map[int]time.Time // Кометы, пересчет которых недавно завершился ошибкой
+}
+
+// StartOrbitScheduler запускает фоновый пересчет до отмены ctx
+func (s *CometsService) StartOrbitScheduler(ctx context.Context, cfg OrbitSchedulerConfig) {
+	if !cfg.Enabled {
+		log.Println("Orbit scheduler is disabled")
+		return
+	}
+	if _, ok := s.orbitMethods.Client(cfg.Method); cfg.Method != "" && !ok {
+		log.Printf("Warning: orbit scheduler method %q is not registered, using default order", cfg.Method)
+		cfg.Method = ""
+	}
+
+	s.scheduler = &orbitScheduler{
+		service: s,
+		cfg:     cfg,
+		status: domain.OrbitSchedulerStatus{
+			Enabled:       true,
+			Interval:      cfg.Interval.String(),
+			BatchSize:     cfg.BatchSize,
+			RatePerMinute: cfg.RatePerMinute,
+		},
+		retryAt: make(map[int]time.Time),
+	}
+	log.Printf("Orbit scheduler started: every %s, up to %d comets, %d per minute", cfg.Interval, cfg.BatchSize, cfg.RatePerMinute)
+	go s.scheduler.run(ctx)
+}
+
+// GetOrbitSchedulerStatus снимок состояния планировщика
+func (s *CometsService) GetOrbitSchedulerStatus() *domain.OrbitSchedulerStatus {
+	if s.scheduler == nil {
+		return &domain.OrbitSchedulerStatus{}
+	}
+	return s.scheduler.snapshot()
+}
+
+func (o *orbitScheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(o.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		o.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce обрабатывает одну выборку устаревших комет с ограничением частоты
+func (o *orbitScheduler) runOnce(ctx context.Context) {
+	started := time.Now()
+	o.update(func(st *domain.OrbitSchedulerStatus) {
+		st.Running = true
+		st.LastRunStartedAt = &started
+	})
+	defer o.update(func(st *domain.OrbitSchedulerStatus) {
+		finished := time.Now()
+		st.Running = false
+		st.LastRunFinishedAt = &finished
+	})
+
+	comets, err := o.service.cometRepo.GetStaleComets(ctx, minOrbitObservations, o.cfg.BatchSize, o.backedOff())
+	if err != nil {
+		o.recordError(0, fmt.Errorf("select stale comets: %w", err))
+		return
+	}
+	o.update(func(st *domain.OrbitSchedulerStatus) { st.LastRunFound = len(comets) })
+
+	limiter := time.NewTicker(time.Minute / time.Duration(o.cfg.RatePerMinute))
+	defer limiter.Stop()
+	first := true
+	// throttle ждет очереди по ограничению частоты; false — работа остановлена
+	throttle := func() bool {
+		if first {
+			first = false
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-limiter.C:
+			return true
+		}
+	}
+
+	for _, comet := range comets {
+		if !throttle() {
+			return
+		}
+
+		err := o.recalculate(ctx, comet.ID)
+		switch {
+		case errors.Is(err, errCometBusy):
+			o.update(func(st *domain.OrbitSchedulerStatus) { st.Skipped++ })
+		case err != nil:
+			o.recordError(comet.ID, err)
+		default:
+			o.update(func(st *domain.OrbitSchedulerStatus) { st.Recalculated++ })
+		}
+	}
+
+	// Пересчитанные только что орбиты тоже попадают в эту выборку
+	pending, err := o.service.cometRepo.GetPendingDynamicsComets(ctx, o.cfg.BatchSize)
+	if err != nil {
+		o.recordError(0, fmt.Errorf("select comets with pending dynamics: %w", err))
+		return
+	}
+	for _, comet := range pending {
+		if !throttle() {
+			return
+		}
+		err := o.computeDynamics(ctx, comet.ID)
+		switch {
+		case errors.Is(err, errCometBusy):
+			o.update(func(st *domain.OrbitSchedulerStatus) { st.Skipped++ })
+		case err != nil:
+			// Комета уже снята с очереди, откладывать ее не нужно
+			o.recordError(0, fmt.Errorf("dynamics of comet %d: %w", comet.ID, err))
+		default:
+			o.update(func(st *domain.OrbitSchedulerStatus) { st.DynamicsComputed++ })
+		}
+	}
+}
+
+// computeDynamics вычисляет 1/a кометы, если ее не занял ручной запрос
+func (o *orbitScheduler) computeDynamics(ctx context.Context, cometID int) error {
+	release, ok := o.service.locks.tryLock(cometID)
+	if !ok {
+		return errCometBusy
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, schedulerCometTimeout)
+	defer cancel()
+
+	comet, err := o.service.cometRepo.GetCometsByID(ctx, cometID)
+	if err != nil {
+		return err
+	}
+	if comet == nil || !comet.DynamicsPending {
+		return nil
+	}
+	return o.service.computeDynamics(ctx, comet)
+}
+
+var errCometBusy = errors.New("comet is being recalculated by another request")
+
+// recalculate пересчитывает орбиту и сближение кометы, если ее не занял ручной запрос
+func (o *orbitScheduler) recalculate(ctx context.Context, cometID int) error {
+	release, ok := o.service.locks.tryLock(cometID)
+	if !ok {
+		return errCometBusy
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, schedulerCometTimeout)
+	defer cancel()
+
+	// Пока комета ждала очереди, орбиту могли пересчитать вручную или удалить комету
+	comet, err := o.service.cometRepo.GetCometsByID(ctx, cometID)
+	if err != nil {
+		return err
+	}
+	if comet == nil || comet.OrbitActual {
+		return nil
+	}
+
+	if _, err := o.service.calculateOrbit(ctx, comet, o.cfg.Method); err != nil {
+		return fmt.Errorf("orbit: %w", err)
+	}
+	if _, err := o.service.calculateCloseApproach(ctx, comet); err != nil {
+		return fmt.Errorf("close approach: %w", err)
+	}
+	log.Printf("Orbit scheduler: recalculated comet %d (%s)", comet.ID, comet.OrbitMethod)
+	return nil
+}
+
+// backedOff кометы, ждущие повтора после ошибки; истекшие отсрочки снимаются
+func (o *orbitScheduler) backedOff() []int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	ids := make([]int, 0, len(o.retryAt))
+	for id, until := range o.retryAt {
+		if now.After(until) {
+			delete(o.retryAt, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	o.status.BackoffComets = len(o.retryAt)
+	return ids
+}
+
+// recordError фиксирует ошибку; комета откладывается на RetryAfter, cometID 0 — ошибка без отложенного повтора
+func (o *orbitScheduler) recordError(cometID int, err error) {
+	log.Printf("Orbit scheduler: comet %d: %v", cometID, err)
+
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.status.LastError = err.Error()
+	o.status.LastErrorAt = &now
+	if cometID == 0 {
+		return
+	}
+	o.retryAt[cometID] = now.Add(o.cfg.RetryAfter)
+	o.status.BackoffComets = len(o.retryAt)
+	o.status.Failed++
+	o.status.LastError = fmt.Sprintf("comet %d: %v", cometID, err)
+}
+
+func (o *orbitScheduler) update(fn func(st *domain.OrbitSchedulerStatus)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	fn(&o.status)
+}
+
+func (o *orbitScheduler) snapshot() *domain.OrbitSchedulerStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	status := o.status
+	return &status
+}
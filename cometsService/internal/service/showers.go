@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/showers"
+)
+
+const (
+	defaultShowerLimit = 10
+	// Узел дальше 0.2 а.е. от орбиты Земли не дает наблюдаемого потока
+	defaultShowerMaxMiss = 0.2
+)
+
+// GetMeteorShowers сравнивает орбиту кометы с орбитами метеорных потоков
+// и вычисляет теоретический радиант для узлов, проходящих вблизи орбиты Земли.
+func (s *CometsService) GetMeteorShowers(ctx context.Context, userID, cometID int, req *domain.MeteorShowerRequest) (*domain.MeteorShowerReport, error) {
+	comet, err := s.cometRepo.GetCometsByID(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if comet.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	elements, ok := cometElements(comet)
+	if !ok {
+		return nil, domain.ErrOrbitNotCalculated
+	}
+
+	criterion, err := orbit.ParseCriterion(req.Criterion)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	threshold := orbit.DefaultThreshold[criterion]
+	if req.Threshold != nil {
+		threshold = *req.Threshold
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultShowerLimit
+	}
+	maxMiss := defaultShowerMaxMiss
+	if req.MaxMiss != nil {
+		maxMiss = *req.MaxMiss
+	}
+
+	catalog, err := s.meteorShowers()
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		association *domain.MeteorShowerAssociation
+		distance    float64
+	}
+	results := make([]scored, 0, len(catalog))
+	for _, shower := range catalog {
+		showerElements := orbit.Elements{
+			Q:    shower.PerihelionDistance,
+			E:    shower.Eccentricity,
+			I:    shower.InclinationDeg,
+			Node: shower.RaanDeg,
+			Peri: shower.ArgumentOfPerihelion,
+		}
+		distance := orbit.Distance(criterion, elements, showerElements)
+		results = append(results, scored{
+			association: &domain.MeteorShowerAssociation{
+				IAUNumber:    shower.IAUNumber,
+				Code:         shower.Code,
+				Name:         shower.Name,
+				ParentBody:   shower.ParentBody,
+				DSH:          orbit.DSH(elements, showerElements),
+				DDrummond:    orbit.DDrummond(elements, showerElements),
+				DH:           orbit.DH(elements, showerElements),
+				LikelyParent: distance <= threshold,
+			},
+			distance: distance,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].distance < results[j].distance
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	report := &domain.MeteorShowerReport{
+		CometID:      comet.ID,
+		Criterion:    string(criterion),
+		Threshold:    threshold,
+		Associations: make([]*domain.MeteorShowerAssociation, len(results)),
+		Radiants:     []*domain.TheoreticalRadiant{},
+	}
+	for i, r := range results {
+		report.Associations[i] = r.association
+	}
+
+	for _, e := range orbit.NodalEncounters(elements, orbit.JulianDate(time.Now()), maxMiss) {
+		node := "descending"
+		if e.Ascending {
+			node = "ascending"
+		}
+		report.Radiants = append(report.Radiants, &domain.TheoreticalRadiant{
+			Node:               node,
+			NodalDistance:      e.NodalDistance,
+			MissDistance:       e.MissDistance,
+			SolarLongitude:     e.SolarLongitude,
+			Date:               orbit.TimeFromJulianDate(e.JD),
+			RightAscension:     e.RadiantRA,
+			Declination:        e.RadiantDec,
+			GeocentricVelocity: e.GeocentricVelocity,
+		})
+	}
+
+	return report, nil
+}
+
+// meteorShowers загружает каталог потоков из METEOR_SHOWERS_FILE или встроенный список IAU MDC
+func (s *CometsService) meteorShowers() ([]*showers.Shower, error) {
+	s.showersOnce.Do(func() {
+		if path := os.Getenv("METEOR_SHOWERS_FILE"); path != "" {
+			s.showers, s.showersErr = showers.LoadFile(path)
+		} else {
+			s.showers, s.showersErr = showers.Default()
+		}
+		if s.showersErr == nil {
+			log.Printf("Loaded %d meteor showers", len(s.showers))
+		}
+	})
+	return s.showers, s.showersErr
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/astrometry"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/synthetic"
+)
+
+// GenerateObservations строит синтетические наблюдения по заданной орбите и пункту наблюдения.
+// Если указано название кометы, наблюдения сохраняются в новую комету пользователя.
+func (s *CometsService) GenerateObservations(ctx context.Context, userID int, req *domain.GenerateObservationsRequest) (*domain.GeneratedObservationsResponse, error) {
+	epoch, err := time.Parse(time.RFC3339, req.OrbitEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: orbit_epoch must be in RFC3339 format", domain.ErrInvalidInput)
+	}
+	start, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start_time must be in RFC3339 format", domain.ErrInvalidInput)
+	}
+	end, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end_time must be in RFC3339 format", domain.ErrInvalidInput)
+	}
+
+	elements := &domain.OrbitalElements{
+		SemiMajorAxis:        req.SemiMajorAxis,
+		Eccentricity:         req.Eccentricity,
+		RaanDeg:              req.RaanDeg,
+		InclinationDeg:       req.InclinationDeg,
+		ArgumentOfPerihelion: req.ArgumentOfPerihelion,
+		TrueAnomalyDeg:       req.TrueAnomalyDeg,
+		Epoch:                &epoch,
+	}
+
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	observations, err := GenerateSyntheticObservations(elements, synthetic.Config{
+		Site: orbit.Site{
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			Altitude:  req.Altitude,
+		},
+		Start:       start,
+		End:         end,
+		Cadence:     time.Duration(req.CadenceHours * float64(time.Hour)),
+		NoiseArcsec: req.NoiseArcsec,
+		Seed:        seed,
+		VisibleOnly: req.VisibleOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obs := range observations {
+		obs.UserID = userID
+	}
+
+	response := &domain.GeneratedObservationsResponse{Observations: observations}
+
+	if name := strings.TrimSpace(req.CometName); name != "" {
+		comet := &domain.Comet{
+			UserID: userID,
+			Name:   name,
+		}
+		if err := s.cometRepo.CreateCometWithObservations(ctx, comet, observations); err != nil {
+			return nil, err
+		}
+		response.Comet = comet
+	}
+
+	return response, nil
+}
+
+// GenerateSyntheticObservations проверяет элементы и генерирует по ним наблюдения без привязки к пользователю.
+// Используется также подкомандой generate-observations и начальным заполнением базы.
+func GenerateSyntheticObservations(elements *domain.OrbitalElements, cfg synthetic.Config) ([]*domain.Observation, error) {
+	if elements.Epoch == nil {
+		return nil, fmt.Errorf("%w: orbit epoch is required", domain.ErrInvalidInput)
+	}
+	if err := elements.Validate(); err != nil {
+		return nil, err
+	}
+
+	el, err := orbit.FromKeplerian(
+		elements.SemiMajorAxis,
+		elements.Eccentricity,
+		elements.InclinationDeg,
+		elements.RaanDeg,
+		elements.ArgumentOfPerihelion,
+		elements.TrueAnomalyDeg,
+		orbit.JulianDate(*elements.Epoch),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	generated, err := synthetic.Generate(el, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	observations := make([]*domain.Observation, len(generated))
+	for i, g := range generated {
+		observations[i] = &domain.Observation{
+			RightAscension: g.RA,
+			Declination:    g.Dec,
+			ObservedAt:     g.Time,
+			Frame:          string(astrometry.FrameICRF),
+		}
+	}
+	return observations, nil
+}
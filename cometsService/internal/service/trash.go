@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+)
+
+const (
+	defaultTrashRetentionDays = 30
+	trashPurgeInterval        = time.Hour
+	trashPurgeBatch           = 100
+)
+
+// trashRetention срок хранения удаленных комет (TRASH_RETENTION_DAYS); 0 — хранить бессрочно
+func trashRetention() time.Duration {
+	days := defaultTrashRetentionDays
+	if v, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && v >= 0 {
+		days = v
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetTrash удаленные кометы пользователя с датой окончательного удаления
+func (s *CometsService) GetTrash(ctx context.Context, userID int) ([]*domain.TrashedComet, error) {
+	comets, err := s.cometRepo.GetDeletedComets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := trashRetention()
+	for _, comet := range comets {
+		if retention > 0 && comet.DeletedAt != nil {
+			purgeAt := comet.DeletedAt.Add(retention)
+			comet.PurgeAt = &purgeAt
+		}
+	}
+	return comets, nil
+}
+
+// RestoreComet возвращает комету из корзины вместе с наблюдениями, сценариями и фото
+func (s *CometsService) RestoreComet(ctx context.Context, userID, cometID int) (*domain.Comet, error) {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	comet, err := s.cometRepo.GetDeletedComet(ctx, cometID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if comet == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	// Пока комета лежала в корзине, ее обозначение могли присвоить другой комете
+	if comet.ScenarioOf == nil {
+		if err := s.checkDesignationFree(ctx, userID, comet.ID, comet.Designation); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cometRepo.RestoreComet(ctx, comet); err != nil {
+		return nil, err
+	}
+	return s.cometRepo.GetCometsByID(ctx, cometID)
+}
+
+// PurgeComet безвозвратно удаляет комету из корзины
+func (s *CometsService) PurgeComet(ctx context.Context, userID, cometID int) error {
+	release, err := s.locks.lock(ctx, cometID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	comet, err := s.cometRepo.GetDeletedComet(ctx, cometID, userID)
+	if err != nil {
+		return err
+	}
+	if comet == nil {
+		return domain.ErrNotFound
+	}
+	return s.purgeComet(ctx, comet)
+}
+
+func (s *CometsService) purgeComet(ctx context.Context, comet *domain.Comet) error {
+	if err := s.cometRepo.PurgeComet(ctx, comet.ID, comet.UserID); err != nil {
+		return err
+	}
+
+	// Фото удаляется только вместе с кометой: до этого ее можно восстановить
+	s.releasePhoto(ctx, comet)
+	return nil
+}
+
+// StartTrashRetention раз в час окончательно удаляет кометы, пролежавшие
+// в корзине дольше TRASH_RETENTION_DAYS, до отмены ctx
+func (s *CometsService) StartTrashRetention(ctx context.Context) {
+	retention := trashRetention()
+	if retention == 0 {
+		log.Println("Trash retention is disabled, deleted comets are kept indefinitely")
+		return
+	}
+	log.Printf("Trash retention: deleted comets are purged after %s", retention)
+
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		for {
+			s.purgeExpiredComets(ctx, retention)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *CometsService) purgeExpiredComets(ctx context.Context, retention time.Duration) {
+	comets, err := s.cometRepo.GetExpiredComets(ctx, time.Now().Add(-retention), trashPurgeBatch)
+	if err != nil {
+		log.Printf("Trash retention: failed to select expired comets: %v", err)
+		return
+	}
+
+	purged := 0
+	for _, comet := range comets {
+		// Занятую комету, например восстанавливаемую сейчас, удалим в следующий проход
+		release, ok := s.locks.tryLock(comet.ID)
+		if !ok {
+			continue
+		}
+		err := s.purgeComet(ctx, comet)
+		release()
+		if err != nil {
+			log.Printf("Trash retention: failed to purge comet %d: %v", comet.ID, err)
+			continue
+		}
+		purged++
+	}
+	if purged > 0 {
+		log.Printf("Trash retention: purged %d comets", purged)
+	}
+}
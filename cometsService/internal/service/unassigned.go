@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/internal/domain"
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+const (
+	// Орбита по короткой дуге быстро теряет точность, поэтому допуск широкий
+	defaultSuggestMaxSeparation = 2.0 // градусы
+	defaultSuggestCandidates    = 3
+)
+
+// GetUnassignedObservations страница наблюдений пользователя, не привязанных к комете
+func (s *CometsService) GetUnassignedObservations(ctx context.Context, userID int, req *domain.ListUnassignedObservationsRequest) (*domain.ObservationPage, error) {
+	page, err := pageRequest(req.PageParams, domain.ObservationSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.ObservationFilter{UserID: userID, Unassigned: true}
+	if filter.From, filter.To, err = observedRange(req.From, req.To); err != nil {
+		return nil, err
+	}
+
+	return s.cometRepo.ListObservations(ctx, filter, page)
+}
+
+// SuggestComets для каждого наблюдения страницы без кометы предвычисляет положения
+// комет пользователя по их сохраненным орбитам и предлагает ближайшие из них.
+// Наблюдения в горизонтальных координатах сравнить не с чем, для них кандидатов нет.
+func (s *CometsService) SuggestComets(ctx context.Context, userID int, req *domain.SuggestCometsRequest) ([]*domain.ObservationSuggestion, *domain.ObservationPage, error) {
+	page, err := s.GetUnassignedObservations(ctx, userID, &req.ListUnassignedObservationsRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxSeparation := defaultSuggestMaxSeparation
+	if req.MaxSeparation != nil {
+		maxSeparation = *req.MaxSeparation
+	}
+	limit := defaultSuggestCandidates
+	if req.Candidates > 0 {
+		limit = req.Candidates
+	}
+
+	comets, err := s.cometRepo.GetCometsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	type cometOrbit struct {
+		comet    *domain.Comet
+		elements orbit.Elements
+	}
+	var orbits []cometOrbit
+	for _, comet := range comets {
+		if elements, ok := storedElements(comet); ok {
+			orbits = append(orbits, cometOrbit{comet, elements})
+		}
+	}
+
+	suggestions := make([]*domain.ObservationSuggestion, len(page.Observations))
+	for i, obs := range page.Observations {
+		suggestion := &domain.ObservationSuggestion{
+			ObservationID: obs.ID,
+			ObservedAt:    obs.ObservedAt,
+			Candidates:    []*domain.CometCandidate{},
+		}
+		suggestions[i] = suggestion
+		if obs.IsHorizontal {
+			continue
+		}
+
+		jd := orbit.JulianDate(obs.ObservedAt)
+		for _, o := range orbits {
+			eph := o.elements.EphemerisAt(jd)
+			separation := orbit.AngularSeparation(obs.RightAscension, obs.Declination, eph.RA, eph.Dec)
+			if separation > maxSeparation {
+				continue
+			}
+			suggestion.Candidates = append(suggestion.Candidates, &domain.CometCandidate{
+				CometID:      o.comet.ID,
+				Name:         o.comet.Name,
+				Designation:  o.comet.Designation,
+				PredictedRA:  eph.RA,
+				PredictedDec: eph.Dec,
+				Separation:   separation,
+				OrbitActual:  o.comet.OrbitActual,
+			})
+		}
+
+		sort.Slice(suggestion.Candidates, func(a, b int) bool {
+			return suggestion.Candidates[a].Separation < suggestion.Candidates[b].Separation
+		})
+		if len(suggestion.Candidates) > limit {
+			suggestion.Candidates = suggestion.Candidates[:limit]
+		}
+	}
+
+	return suggestions, page, nil
+}
+
+// AssignObservations привязывает наблюдения без кометы к комете пользователя
+func (s *CometsService) AssignObservations(ctx context.Context, userID int, req *domain.AssignObservationsRequest) (*domain.Comet, error) {
+	release, err := s.locks.lock(ctx, req.CometID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	comet, err := s.getOwnedComet(ctx, userID, req.CometID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := uniqueInts(req.ObservationIDs)
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"comet_id":        comet.ID,
+		"observation_ids": ids,
+	})
+	audit := &domain.AuditEntry{
+		UserID:  userID,
+		CometID: comet.ID,
+		Action:  domain.AuditActionAssign,
+		Details: string(details),
+	}
+
+	if err := s.cometRepo.AssignObservations(ctx, comet.ID, userID, ids, audit); err != nil {
+		return nil, err
+	}
+
+	// Новые оценки блеска могут изменить закон блеска и найденные вспышки
+	s.checkOutbursts(ctx, &comet.ID, userID)
+
+	return s.cometRepo.GetCometsByID(ctx, comet.ID)
+}
@@ -0,0 +1,110 @@
+// Package astrometry приводит координаты наблюдений, заданные в разных системах
+// отсчета, к ICRF: учитывает прецессию, нутацию, годичную аберрацию и переход
+// от каталожной системы FK4 (B1950) к FK5.
+package astrometry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// Frame система отсчета, в которой заданы прямое восхождение и склонение
+type Frame string
+
+const (
+	// FrameICRF ICRF; средний экватор и равноденствие J2000 (FK5) считаются с ней
+	// совпадающими — расхождение осей не превышает 0.03″
+	FrameICRF Frame = "ICRF"
+	// FrameB1950 средний экватор и равноденствие B1950 каталога FK4
+	FrameB1950 Frame = "B1950"
+	// FrameApparent видимые координаты: истинный экватор и равноденствие даты наблюдения
+	FrameApparent Frame = "APPARENT"
+)
+
+const jdJ2000 = orbit.JDJ2000
+
+var ErrUnknownFrame = errors.New("unknown reference frame")
+
+// ParseFrame разбирает название системы отсчета. Пустая строка означает ICRF;
+// принимаются также синонимы J2000, ICRS, FK5, FK4 и OF-DATE.
+func ParseFrame(s string) (Frame, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "", "ICRF", "ICRS", "J2000", "FK5":
+		return FrameICRF, nil
+	case "B1950", "FK4":
+		return FrameB1950, nil
+	case "APPARENT", "OF-DATE", "OF_DATE":
+		return FrameApparent, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFrame, s)
+	}
+}
+
+// ToICRF переводит координаты (градусы) из системы frame в ICRF.
+// jdTT — момент наблюдения, нужен для видимых координат.
+func ToICRF(raDeg, decDeg float64, frame Frame, jdTT float64) (float64, float64, error) {
+	u := orbit.UnitVector(raDeg, decDeg)
+
+	switch frame {
+	case FrameICRF:
+		return raDeg, decDeg, nil
+	case FrameB1950:
+		u = fk4ToFK5(u)
+	case FrameApparent:
+		u = Precession(jdTT).Transpose().Apply(NutationMatrix(jdTT).Transpose().Apply(u))
+		u = removeAberration(u, jdTT)
+	default:
+		return 0, 0, fmt.Errorf("%w: %q", ErrUnknownFrame, frame)
+	}
+
+	ra, dec := orbit.RaDec(u)
+	return ra, dec, nil
+}
+
+// Компоненты E-членов аберрации, включенных в положения каталога FK4, радианы
+var fk4ETerms = [3]float64{-1.62557e-6, -0.31919e-6, -0.13843e-6}
+
+// fk4ToFK5Matrix поворот от FK4 B1950 к FK5 J2000 для эпохи B1950
+// (Standish 1982; Aoki et al. 1983) без учета собственных движений
+var fk4ToFK5Matrix = Matrix{
+	{0.9999256782, -0.0111820611, -0.0048579477},
+	{0.0111820610, 0.9999374784, -0.0000271765},
+	{0.0048579479, -0.0000271474, 0.9999881997},
+}
+
+// fk4ToFK5 убирает E-члены аберрации и переводит направление в FK5 J2000
+func fk4ToFK5(u [3]float64) [3]float64 {
+	k := orbit.Dot(u, fk4ETerms)
+	v := [3]float64{
+		u[0] - fk4ETerms[0] + k*u[0],
+		u[1] - fk4ETerms[1] + k*u[1],
+		u[2] - fk4ETerms[2] + k*u[2],
+	}
+	return fk4ToFK5Matrix.Apply(normalize(v))
+}
+
+// removeAberration исключает годичную аберрацию (первый порядок по v/c)
+// из направления, заданного в экваториальной системе J2000
+func removeAberration(u [3]float64, jdTT float64) [3]float64 {
+	velocity := orbit.EclipticToEquatorial(orbit.EarthState(jdTT).Velocity)
+	beta := orbit.Scale(velocity, 1/orbit.SpeedOfLight)
+
+	k := orbit.Dot(u, beta)
+	return normalize([3]float64{
+		u[0] - beta[0] + k*u[0],
+		u[1] - beta[1] + k*u[1],
+		u[2] - beta[2] + k*u[2],
+	})
+}
+
+func normalize(v [3]float64) [3]float64 {
+	n := orbit.Norm(v)
+	if n == 0 || math.IsNaN(n) {
+		return v
+	}
+	return orbit.Scale(v, 1/n)
+}
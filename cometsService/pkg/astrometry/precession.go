@@ -0,0 +1,83 @@
+package astrometry
+
+import "math"
+
+// julianCenturies время от J2000.0 в юлианских столетиях TT
+func julianCenturies(jdTT float64) float64 {
+	return (jdTT - jdJ2000) / 36525
+}
+
+// Precession матрица прецессии IAU 1976 (Lieske) от среднего экватора и
+// равноденствия J2000 к среднему экватору и равноденствию даты jdTT
+func Precession(jdTT float64) Matrix {
+	t := julianCenturies(jdTT)
+	zeta := (2306.2181*t + 0.30188*t*t + 0.017998*t*t*t) * arcsec
+	z := (2306.2181*t + 1.09468*t*t + 0.018203*t*t*t) * arcsec
+	theta := (2004.3109*t - 0.42665*t*t - 0.041833*t*t*t) * arcsec
+
+	return rotZ(-z).Mul(rotY(theta)).Mul(rotZ(-zeta))
+}
+
+// MeanObliquity средний наклон эклиптики к экватору даты (IAU 1980), радианы
+func MeanObliquity(jdTT float64) float64 {
+	t := julianCenturies(jdTT)
+	return (84381.448 - 46.8150*t - 0.00059*t*t + 0.001813*t*t*t) * arcsec
+}
+
+// nutationTerm член ряда нутации IAU 1980: множители аргументов D, M, M', F, Ω
+// и амплитуды в единицах 0.0001″ (с вековыми изменениями на столетие)
+type nutationTerm struct {
+	d, m, mp, f, om      float64
+	psi, psiT, eps, epsT float64
+}
+
+// Главные члены теории нутации IAU 1980; отброшенные дают ошибку не более 0.01″
+var nutationTerms = []nutationTerm{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+	{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+	{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+	{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+	{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+	{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+	{0, 0, -1, 2, 2, 123, 0, -53, 0},
+	{2, 0, 0, 0, 0, 63, 0, 0, 0},
+	{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+	{2, 0, -1, 2, 2, -59, 0, 26, 0},
+	{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+	{0, 0, 1, 2, 1, -51, 0, 27, 0},
+	{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+	{0, 0, -2, 2, 1, 46, 0, -24, 0},
+	{2, 0, 0, 2, 2, -38, 0, 16, 0},
+}
+
+// Nutation нутация в долготе и наклоне на дату jdTT, радианы
+func Nutation(jdTT float64) (dPsi, dEps float64) {
+	t := julianCenturies(jdTT)
+
+	// Фундаментальные аргументы Луны и Солнца, градусы
+	d := 297.85036 + 445267.111480*t - 0.0019142*t*t + t*t*t/189474
+	m := 357.52772 + 35999.050340*t - 0.0001603*t*t - t*t*t/300000
+	mp := 134.96298 + 477198.867398*t + 0.0086972*t*t + t*t*t/56250
+	f := 93.27191 + 483202.017538*t - 0.0036825*t*t + t*t*t/327270
+	om := 125.04452 - 1934.136261*t + 0.0020708*t*t + t*t*t/450000
+
+	for _, n := range nutationTerms {
+		arg := (n.d*d + n.m*m + n.mp*mp + n.f*f + n.om*om) * deg
+		dPsi += (n.psi + n.psiT*t) * math.Sin(arg)
+		dEps += (n.eps + n.epsT*t) * math.Cos(arg)
+	}
+	return dPsi * 1e-4 * arcsec, dEps * 1e-4 * arcsec
+}
+
+// NutationMatrix матрица перехода от среднего к истинному экватору даты jdTT
+func NutationMatrix(jdTT float64) Matrix {
+	eps := MeanObliquity(jdTT)
+	dPsi, dEps := Nutation(jdTT)
+	return rotX(-(eps + dEps)).Mul(rotZ(-dPsi)).Mul(rotX(eps))
+}
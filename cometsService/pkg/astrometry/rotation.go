@@ -0,0 +1,63 @@
+package astrometry
+
+import "math"
+
+const (
+	deg    = math.Pi / 180
+	arcsec = deg / 3600
+)
+
+// Matrix матрица поворота 3×3
+type Matrix [3][3]float64
+
+// Identity единичная матрица
+var Identity = Matrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// Apply применяет поворот к вектору
+func (m Matrix) Apply(v [3]float64) [3]float64 {
+	var r [3]float64
+	for i := range 3 {
+		r[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2]
+	}
+	return r
+}
+
+// Mul произведение m·n: сначала применяется n, затем m
+func (m Matrix) Mul(n Matrix) Matrix {
+	var r Matrix
+	for i := range 3 {
+		for j := range 3 {
+			r[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return r
+}
+
+// Transpose транспонированная матрица; для поворота — обратный поворот
+func (m Matrix) Transpose() Matrix {
+	var r Matrix
+	for i := range 3 {
+		for j := range 3 {
+			r[i][j] = m[j][i]
+		}
+	}
+	return r
+}
+
+// rotX поворот системы координат вокруг оси X на угол a (радианы)
+func rotX(a float64) Matrix {
+	c, s := math.Cos(a), math.Sin(a)
+	return Matrix{{1, 0, 0}, {0, c, s}, {0, -s, c}}
+}
+
+// rotY поворот системы координат вокруг оси Y на угол a (радианы)
+func rotY(a float64) Matrix {
+	c, s := math.Cos(a), math.Sin(a)
+	return Matrix{{c, 0, -s}, {0, 1, 0}, {s, 0, c}}
+}
+
+// rotZ поворот системы координат вокруг оси Z на угол a (радианы)
+func rotZ(a float64) Matrix {
+	c, s := math.Cos(a), math.Sin(a)
+	return Matrix{{c, s, 0}, {-s, c, 0}, {0, 0, 1}}
+}
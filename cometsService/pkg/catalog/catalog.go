@@ -0,0 +1,65 @@
+// Package catalog разбирает справочные каталоги комет:
+// MPC CometEls.txt и выгрузки JPL SBDB в формате JSON.
+package catalog
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	FormatMPC = "mpc"
+	FormatJPL = "jpl"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+// Entry одна запись каталога в перигелийных элементах (эклиптика J2000)
+type Entry struct {
+	Designation          string
+	Name                 string
+	PerihelionDistance   float64
+	Eccentricity         float64
+	InclinationDeg       float64
+	RaanDeg              float64
+	ArgumentOfPerihelion float64
+	PerihelionTime       time.Time
+	Epoch                *time.Time
+	AbsoluteMagnitude    *float64 // M1 (H для MPC)
+	SlopeParameter       *float64 // K1 (G для MPC)
+	Reference            string
+}
+
+// periodicPrefix распознает обозначения вида "1P/Halley" и "73P-B/Schwassmann-Wachmann"
+var periodicPrefix = regexp.MustCompile(`^(\d+[PDI](?:-[A-Z]{1,2})?)/(.*)$`)
+
+// splitDesignation делит строку "обозначение и имя" на обозначение и собственное имя
+func splitDesignation(full string) (string, string) {
+	full = strings.TrimSpace(full)
+
+	if m := periodicPrefix.FindStringSubmatch(full); m != nil {
+		return m[1], strings.TrimSpace(m[2])
+	}
+
+	// "C/2020 F3 (NEOWISE)" или "(12345) 2000 AB1"
+	if open := strings.LastIndex(full, "("); open > 0 && strings.HasSuffix(full, ")") {
+		return strings.TrimSpace(full[:open]), strings.TrimSpace(full[open+1 : len(full)-1])
+	}
+
+	return full, ""
+}
+
+// Parse разбирает каталог в указанном формате (mpc или jpl)
+func Parse(format string, r io.Reader) ([]*Entry, error) {
+	switch strings.ToLower(format) {
+	case FormatMPC:
+		return ParseMPC(r)
+	case FormatJPL:
+		return ParseJPL(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
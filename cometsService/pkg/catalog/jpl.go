@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// sbdbDump формат ответа JPL SBDB Query API (sbdb_query.api)
+type sbdbDump struct {
+	Fields []string            `json:"fields"`
+	Data   [][]json.RawMessage `json:"data"`
+}
+
+// ParseJPL разбирает выгрузку JPL SBDB Query API.
+// Обязательные поля: full_name, q, e, i, om, w, tp; необязательные: epoch, M1, K1.
+func ParseJPL(r io.Reader) ([]*Entry, error) {
+	var dump sbdbDump
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return nil, fmt.Errorf("invalid SBDB JSON: %w", err)
+	}
+
+	index := make(map[string]int, len(dump.Fields))
+	for i, f := range dump.Fields {
+		index[f] = i
+	}
+	for _, required := range []string{"full_name", "q", "e", "i", "om", "w", "tp"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("SBDB dump has no %q field", required)
+		}
+	}
+
+	entries := make([]*Entry, 0, len(dump.Data))
+	for row, values := range dump.Data {
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			var s string
+			if err := json.Unmarshal(values[i], &s); err == nil {
+				return strings.TrimSpace(s)
+			}
+			// Числа могут прийти без кавычек, null превращается в пустую строку
+			raw := strings.TrimSpace(string(values[i]))
+			if raw == "null" {
+				return ""
+			}
+			return raw
+		}
+
+		entry := &Entry{}
+		entry.Designation, entry.Name = splitDesignation(get("full_name"))
+		if entry.Designation == "" {
+			return nil, fmt.Errorf("row %d: missing full_name", row+1)
+		}
+
+		fields := []struct {
+			name   string
+			target *float64
+		}{
+			{"q", &entry.PerihelionDistance},
+			{"e", &entry.Eccentricity},
+			{"i", &entry.InclinationDeg},
+			{"om", &entry.RaanDeg},
+			{"w", &entry.ArgumentOfPerihelion},
+		}
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(get(f.name), 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid %s", row+1, f.name)
+			}
+			*f.target = v
+		}
+
+		tp, err := strconv.ParseFloat(get("tp"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid tp", row+1)
+		}
+		// tp и epoch в SBDB — юлианские даты TDB
+		entry.PerihelionTime = orbit.TimeFromJulianDate(tp)
+
+		if epoch, err := strconv.ParseFloat(get("epoch"), 64); err == nil {
+			t := orbit.TimeFromJulianDate(epoch)
+			entry.Epoch = &t
+		}
+		entry.AbsoluteMagnitude = optionalFloat(get("M1"))
+		entry.SlopeParameter = optionalFloat(get("K1"))
+		entry.Reference = "JPL SBDB"
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
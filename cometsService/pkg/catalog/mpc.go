@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/timescale"
+)
+
+// ParseMPC разбирает файл CometEls.txt Центра малых планет.
+// Формат с фиксированными колонками описан на
+// https://www.minorplanetcenter.net/iau/info/CometOrbitFormat.html
+func ParseMPC(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		entry, err := parseMPCLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func parseMPCLine(line string) (*Entry, error) {
+	if len(line) < 103 {
+		return nil, fmt.Errorf("line is too short (%d characters)", len(line))
+	}
+
+	year, err := intColumn(line, 15, 18, "perihelion year")
+	if err != nil {
+		return nil, err
+	}
+	month, err := intColumn(line, 20, 21, "perihelion month")
+	if err != nil {
+		return nil, err
+	}
+	day, err := floatColumn(line, 23, 29, "perihelion day")
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		PerihelionTime: timescale.ToUTC(fractionalDate(year, month, day), timescale.TT),
+	}
+
+	fields := []struct {
+		from, to int
+		name     string
+		target   *float64
+	}{
+		{31, 39, "perihelion distance", &entry.PerihelionDistance},
+		{42, 49, "eccentricity", &entry.Eccentricity},
+		{52, 59, "argument of perihelion", &entry.ArgumentOfPerihelion},
+		{62, 69, "longitude of ascending node", &entry.RaanDeg},
+		{72, 79, "inclination", &entry.InclinationDeg},
+	}
+	for _, f := range fields {
+		if *f.target, err = floatColumn(line, f.from, f.to, f.name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Эпоха возмущенного решения указывается не для всех комет
+	if epoch := column(line, 82, 89); epoch != "" {
+		t, err := time.Parse("20060102", epoch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid epoch %q", epoch)
+		}
+		t = timescale.ToUTC(t, timescale.TT)
+		entry.Epoch = &t
+	}
+
+	entry.AbsoluteMagnitude = optionalFloat(column(line, 92, 95))
+	entry.SlopeParameter = optionalFloat(column(line, 97, 100))
+	entry.Designation, entry.Name = splitDesignation(column(line, 103, 158))
+	entry.Reference = column(line, 160, 168)
+
+	if entry.Designation == "" {
+		return nil, fmt.Errorf("missing designation")
+	}
+
+	return entry, nil
+}
+
+// column возвращает содержимое колонок from..to (нумерация с 1, включительно)
+func column(line string, from, to int) string {
+	if from > len(line) {
+		return ""
+	}
+	if to > len(line) {
+		to = len(line)
+	}
+	return strings.TrimSpace(line[from-1 : to])
+}
+
+func intColumn(line string, from, to int, name string) (int, error) {
+	v, err := strconv.Atoi(column(line, from, to))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return v, nil
+}
+
+func floatColumn(line string, from, to int, name string) (float64, error) {
+	v, err := strconv.ParseFloat(column(line, from, to), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return v, nil
+}
+
+func optionalFloat(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// fractionalDate строит показание часов по году, месяцу и дробному дню месяца.
+// Даты в файлах MPC заданы в шкале TT.
+func fractionalDate(year, month int, day float64) time.Time {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration((day - 1) * float64(24*time.Hour)))
+}
@@ -0,0 +1,212 @@
+// Package coords разбирает экваториальные координаты, введенные наблюдателем:
+// десятичные градусы, десятичные часы и шестидесятеричную запись
+// ("12 34 56.7", "12h34m56.7s", "-05°30'15\"", "12:34:56.7").
+package coords
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Unit единица, в которой задано значение координаты
+type Unit string
+
+const (
+	// UnitAuto градусы для одиночного числа, иначе шестидесятеричная запись
+	UnitAuto Unit = ""
+	// UnitDegrees десятичные градусы
+	UnitDegrees Unit = "deg"
+	// UnitHours десятичные часы (только для прямого восхождения)
+	UnitHours Unit = "hours"
+	// UnitSexagesimal часы-минуты-секунды для прямого восхождения,
+	// градусы-минуты-секунды для склонения
+	UnitSexagesimal Unit = "sexagesimal"
+)
+
+var (
+	ErrUnknownUnit   = errors.New("unknown coordinate unit")
+	ErrInvalidFormat = errors.New("invalid coordinate format")
+	ErrOutOfRange    = errors.New("coordinate out of range")
+)
+
+// ParseUnit разбирает название единицы; допускаются синонимы degrees, h, hms, dms
+func ParseUnit(s string) (Unit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return UnitAuto, nil
+	case "deg", "degree", "degrees", "d":
+		return UnitDegrees, nil
+	case "hours", "hour", "h":
+		return UnitHours, nil
+	case "sexagesimal", "hms", "dms":
+		return UnitSexagesimal, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected deg, hours or sexagesimal)", ErrUnknownUnit, s)
+	}
+}
+
+// ParseRA разбирает прямое восхождение и возвращает его в градусах [0, 360)
+func ParseRA(s string, unit Unit) (float64, error) {
+	s = strings.TrimSpace(s)
+	if unit == UnitAuto {
+		unit = detectUnit(s)
+	}
+
+	var deg float64
+	switch unit {
+	case UnitDegrees:
+		v, err := parseDecimal(s)
+		if err != nil {
+			return 0, err
+		}
+		deg = v
+	case UnitHours:
+		v, err := parseDecimal(s)
+		if err != nil {
+			return 0, err
+		}
+		if v < 0 || v > 24 {
+			return 0, fmt.Errorf("%w: %s hours is outside 0..24", ErrOutOfRange, format(v))
+		}
+		deg = v * 15
+	case UnitSexagesimal:
+		negative, fields, err := parseSexagesimal(s)
+		if err != nil {
+			return 0, err
+		}
+		if negative {
+			return 0, fmt.Errorf("%w: right ascension cannot be negative", ErrOutOfRange)
+		}
+		if fields[0] >= 24 {
+			return 0, fmt.Errorf("%w: %s hours is outside 0..23", ErrOutOfRange, format(fields[0]))
+		}
+		deg = (fields[0] + fields[1]/60 + fields[2]/3600) * 15
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+	}
+
+	return ValidateRA(deg)
+}
+
+// ParseDec разбирает склонение и возвращает его в градусах [-90, 90]
+func ParseDec(s string, unit Unit) (float64, error) {
+	s = strings.TrimSpace(s)
+	if unit == UnitAuto {
+		unit = detectUnit(s)
+	}
+
+	var deg float64
+	switch unit {
+	case UnitDegrees:
+		v, err := parseDecimal(s)
+		if err != nil {
+			return 0, err
+		}
+		deg = v
+	case UnitHours:
+		return 0, fmt.Errorf("%w: declination cannot be given in hours", ErrUnknownUnit)
+	case UnitSexagesimal:
+		negative, fields, err := parseSexagesimal(s)
+		if err != nil {
+			return 0, err
+		}
+		deg = fields[0] + fields[1]/60 + fields[2]/3600
+		if negative {
+			deg = -deg
+		}
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+	}
+
+	return ValidateDec(deg)
+}
+
+// ValidateRA проверяет прямое восхождение в градусах; 360 приводится к 0
+func ValidateRA(deg float64) (float64, error) {
+	if math.IsNaN(deg) || deg < 0 || deg > 360 {
+		return 0, fmt.Errorf("%w: right ascension %s° is outside 0..360", ErrOutOfRange, format(deg))
+	}
+	if deg == 360 {
+		return 0, nil
+	}
+	return deg, nil
+}
+
+// ValidateDec проверяет склонение в градусах
+func ValidateDec(deg float64) (float64, error) {
+	if math.IsNaN(deg) || deg < -90 || deg > 90 {
+		return 0, fmt.Errorf("%w: declination %s° is outside -90..90", ErrOutOfRange, format(deg))
+	}
+	return deg, nil
+}
+
+// detectUnit считает одиночное число градусами, все остальное — шестидесятеричной записью
+func detectUnit(s string) Unit {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return UnitDegrees
+	}
+	return UnitSexagesimal
+}
+
+func parseDecimal(s string) (float64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty value", ErrInvalidFormat)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidFormat, s)
+	}
+	return v, nil
+}
+
+// Разделители шестидесятеричной записи
+var separators = strings.NewReplacer(
+	"h", " ", "H", " ", "d", " ", "D", " ", "°", " ",
+	"m", " ", "M", " ", "'", " ", "′", " ",
+	"s", " ", "S", " ", "\"", " ", "″", " ",
+	":", " ",
+)
+
+// parseSexagesimal разбирает 1–3 поля "старшая единица, минуты, секунды".
+// Знак относится ко всей записи, поэтому "-00 30 00" — это минус полградуса.
+func parseSexagesimal(s string) (bool, [3]float64, error) {
+	var fields [3]float64
+	text := strings.TrimSpace(s)
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"), strings.HasPrefix(text, "−"):
+		negative = true
+		text = strings.TrimLeft(text, "-−")
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	parts := strings.Fields(separators.Replace(text))
+	if len(parts) == 0 || len(parts) > 3 {
+		return false, fields, fmt.Errorf("%w: %q (expected 1 to 3 sexagesimal fields)", ErrInvalidFormat, s)
+	}
+
+	names := [3]string{"degrees or hours", "minutes", "seconds"}
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || v < 0 || math.IsInf(v, 0) {
+			return false, fields, fmt.Errorf("%w: %q has invalid %s field %q", ErrInvalidFormat, s, names[i], p)
+		}
+		// Дробная часть допустима только в последнем поле
+		if i < len(parts)-1 && v != math.Trunc(v) {
+			return false, fields, fmt.Errorf("%w: %q has fractional %s but further fields", ErrInvalidFormat, s, names[i])
+		}
+		if i > 0 && v >= 60 {
+			return false, fields, fmt.Errorf("%w: %s %s in %q must be below 60", ErrOutOfRange, names[i], format(v), s)
+		}
+		fields[i] = v
+	}
+	return negative, fields, nil
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,111 @@
+package coords
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseRA(t *testing.T) {
+	tests := []struct {
+		input string
+		unit  Unit
+		want  float64
+	}{
+		{"12h34m56.7s", UnitAuto, 188.73625},
+		{"12 34 56.7", UnitAuto, 188.73625},
+		{"12:34:56.7", UnitSexagesimal, 188.73625},
+		{"12 34.945", UnitAuto, 188.73625},
+		{"188.73625", UnitAuto, 188.73625},
+		{"12.5", UnitHours, 187.5},
+		{"0", UnitAuto, 0},
+		{"0h0m0s", UnitAuto, 0},
+		{"360", UnitDegrees, 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseRA(tt.input, tt.unit)
+		if err != nil {
+			t.Errorf("ParseRA(%q, %q) error: %v", tt.input, tt.unit, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ParseRA(%q, %q) = %v, want %v", tt.input, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestParseDec(t *testing.T) {
+	tests := []struct {
+		input string
+		unit  Unit
+		want  float64
+	}{
+		{"-00 30 00", UnitAuto, -0.5},
+		{"−00 30 00", UnitAuto, -0.5},
+		{"+00 30 00", UnitAuto, 0.5},
+		{"-05°30'15\"", UnitAuto, -5.5041666666666667},
+		{"45:00:36", UnitSexagesimal, 45.01},
+		{"0", UnitAuto, 0},
+		{"0", UnitDegrees, 0},
+		{"00 00 00", UnitAuto, 0},
+		{"-0", UnitAuto, 0},
+		{"90", UnitAuto, 90},
+		{"-90 00 00", UnitAuto, -90},
+	}
+	for _, tt := range tests {
+		got, err := ParseDec(tt.input, tt.unit)
+		if err != nil {
+			t.Errorf("ParseDec(%q, %q) error: %v", tt.input, tt.unit, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ParseDec(%q, %q) = %v, want %v", tt.input, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func() (float64, error)
+		want  error
+	}{
+		{"ra 24h", func() (float64, error) { return ParseRA("24 00 00", UnitAuto) }, ErrOutOfRange},
+		{"ra negative", func() (float64, error) { return ParseRA("-01 00 00", UnitAuto) }, ErrOutOfRange},
+		{"ra 25 hours", func() (float64, error) { return ParseRA("25", UnitHours) }, ErrOutOfRange},
+		{"ra 60 minutes", func() (float64, error) { return ParseRA("12 60 00", UnitAuto) }, ErrOutOfRange},
+		{"ra fractional minutes", func() (float64, error) { return ParseRA("12 34.5 10", UnitAuto) }, ErrInvalidFormat},
+		{"ra empty", func() (float64, error) { return ParseRA("", UnitDegrees) }, ErrInvalidFormat},
+		{"ra four fields", func() (float64, error) { return ParseRA("1 2 3 4", UnitAuto) }, ErrInvalidFormat},
+		{"dec 91", func() (float64, error) { return ParseDec("91", UnitAuto) }, ErrOutOfRange},
+		{"dec -90 00 01", func() (float64, error) { return ParseDec("-90 00 01", UnitAuto) }, ErrOutOfRange},
+		{"dec hours", func() (float64, error) { return ParseDec("1", UnitHours) }, ErrUnknownUnit},
+		{"dec letters", func() (float64, error) { return ParseDec("north", UnitAuto) }, ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		if _, err := tt.parse(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	tests := map[string]Unit{
+		"":            UnitAuto,
+		"deg":         UnitDegrees,
+		"Degrees":     UnitDegrees,
+		"h":           UnitHours,
+		"hms":         UnitSexagesimal,
+		" dms ":       UnitSexagesimal,
+		"sexagesimal": UnitSexagesimal,
+	}
+	for input, want := range tests {
+		got, err := ParseUnit(input)
+		if err != nil || got != want {
+			t.Errorf("ParseUnit(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseUnit("radians"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("ParseUnit(radians) error = %v, want ErrUnknownUnit", err)
+	}
+}
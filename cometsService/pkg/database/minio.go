@@ -150,8 +150,11 @@ func (m *MinioClient) UploadPhoto(ctx context.Context, userID int, fileData []by
 }
 
 func (m *MinioClient) DeletePhoto(ctx context.Context, photoURL string) error {
-	// Извлекаем objectName из полного URL
-	objectName := m.extractObjectNameFromURL(photoURL)
+	// Извлекаем objectName из полного URL; чужие адреса не трогаем
+	objectName, ok := m.extractObjectNameFromURL(photoURL)
+	if !ok {
+		return fmt.Errorf("photo URL %q does not point to bucket %s", photoURL, m.BucketName)
+	}
 
 	err := m.Client.RemoveObject(ctx, m.BucketName, objectName, minio.RemoveObjectOptions{})
 	if err != nil {
@@ -166,6 +169,15 @@ func (m *MinioClient) GetPhotoURL(ctx context.Context, photoURL string) (string,
 	return photoURL, nil
 }
 
+// IsUserPhoto сообщает, указывает ли ссылка на объект этого бакета в папке пользователя images/user_<id>/
+func (m *MinioClient) IsUserPhoto(photoURL string, userID int) bool {
+	objectName, ok := m.extractObjectNameFromURL(photoURL)
+	if !ok || strings.Contains(objectName, "..") {
+		return false
+	}
+	return strings.HasPrefix(objectName, fmt.Sprintf("images/user_%d/", userID))
+}
+
 // Вспомогательные методы
 
 func (m *MinioClient) getImageURL(objectName string) string {
@@ -180,21 +192,21 @@ func (m *MinioClient) generateImageName(userID int, originalFilename string) str
 	return fmt.Sprintf("images/user_%d/%d%s", userID, timestamp, ext)
 }
 
-func (m *MinioClient) extractObjectNameFromURL(photoURL string) string {
+func (m *MinioClient) extractObjectNameFromURL(photoURL string) (string, bool) {
 	// Извлекаем objectName из полного URL
 	// Пример: http://localhost:9000/comet-images/images/user_1/1234567890.jpg -> images/user_1/1234567890.jpg
 	
 	// Пробуем с публичным endpoint
 	prefix := fmt.Sprintf("http://%s/%s/", m.PublicEndpoint, m.BucketName)
 	if strings.HasPrefix(photoURL, prefix) {
-		return strings.TrimPrefix(photoURL, prefix)
+		return strings.TrimPrefix(photoURL, prefix), true
 	}
 	
 	// Пробуем с внутренним endpoint (для обратной совместимости)
 	prefix = fmt.Sprintf("http://%s/%s/", m.Endpoint, m.BucketName)
 	if strings.HasPrefix(photoURL, prefix) {
-		return strings.TrimPrefix(photoURL, prefix)
+		return strings.TrimPrefix(photoURL, prefix), true
 	}
 	
-	return photoURL // Если не удалось извлечь, возвращаем как есть
+	return "", false
 }
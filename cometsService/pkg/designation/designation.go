@@ -0,0 +1,194 @@
+// Package designation разбирает и нормализует обозначения комет по правилам МАС:
+// нумерованные периодические ("1P", "73P-B"), предварительные ("C/2020 F3",
+// "C/2019 Y4-B") и упакованную форму Центра малых планет ("0001P", "CK20F030").
+package designation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid comet designation")
+
+// Designation разобранное обозначение кометы
+type Designation struct {
+	Type      byte   // P, C, D, X, I или A
+	Number    int    // Номер периодической кометы; 0 для предварительного обозначения
+	Year      int    // Год открытия (предварительное обозначение)
+	HalfMonth byte   // Буква полумесяца открытия A–Y без I
+	Order     int    // Порядковый номер открытия в полумесяце
+	Fragment  string // Обозначение фрагмента: "B", "AA"; пусто для целой кометы
+	Name      string // Имя, если оно было указано ("Halley", "NEOWISE"); в ключ не входит
+}
+
+var (
+	numberedRe    = regexp.MustCompile(`^0*([1-9]\d*)\s*([PDI])(?:-?([A-Z]{1,2}))?(?:\s*/\s*(.*))?$`)
+	provisionalRe = regexp.MustCompile(`^([PCDXIA])\s*/?\s*(\d{4})\s*([A-HJ-Y])\s*0*([1-9]\d*)(?:-([A-Z]{1,2}))?(?:\s*/\s*(.*))?$`)
+	packedRe      = regexp.MustCompile(`^([PCDXIA])([IJK])(\d{2})([A-HJ-Y])([0-9A-Za-z])(\d)([a-z0])$`)
+	trailingName  = regexp.MustCompile(`\s*\(([^()]*)\)\s*$`)
+)
+
+// Parse разбирает обозначение в любой из поддерживаемых форм без учета регистра:
+// "C/2020 F3", "c2020f3", "C/2020 F3 (NEOWISE)", "1P/Halley", "0073P", "73P-B", "CK20F030".
+func Parse(s string) (Designation, error) {
+	text := strings.TrimSpace(s)
+	var name string
+	if m := trailingName.FindStringSubmatch(text); m != nil {
+		name = strings.TrimSpace(m[1])
+		text = strings.TrimSpace(text[:len(text)-len(m[0])])
+	}
+
+	if m := packedRe.FindStringSubmatch(text); m != nil {
+		return parsePacked(m, name)
+	}
+
+	upper := strings.ToUpper(text)
+	if m := numberedRe.FindStringSubmatch(upper); m != nil {
+		number, _ := strconv.Atoi(m[1])
+		d := Designation{Type: m[2][0], Number: number, Fragment: m[3], Name: name}
+		if m[4] != "" {
+			d.Name = originalCase(text, m[4])
+		}
+		return d, nil
+	}
+
+	if m := provisionalRe.FindStringSubmatch(upper); m != nil {
+		year, _ := strconv.Atoi(m[2])
+		order, _ := strconv.Atoi(m[4])
+		d := Designation{Type: m[1][0], Year: year, HalfMonth: m[3][0], Order: order, Fragment: m[5], Name: name}
+		if m[6] != "" {
+			d.Name = originalCase(text, m[6])
+		}
+		return d, d.validateProvisional(s)
+	}
+
+	return Designation{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+}
+
+// Normalize возвращает каноническую запись обозначения
+func Normalize(s string) (string, error) {
+	d, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return d.String(), nil
+}
+
+// String каноническая запись без имени: "1P", "73P-B", "C/2020 F3", "C/2019 Y4-B"
+func (d Designation) String() string {
+	var b strings.Builder
+	if d.Number > 0 {
+		fmt.Fprintf(&b, "%d%c", d.Number, d.Type)
+	} else {
+		fmt.Fprintf(&b, "%c/%d %c%d", d.Type, d.Year, d.HalfMonth, d.Order)
+	}
+	if d.Fragment != "" {
+		b.WriteString("-" + d.Fragment)
+	}
+	return b.String()
+}
+
+// FullName каноническая запись с именем, если оно известно: "1P/Halley", "C/2020 F3 (NEOWISE)"
+func (d Designation) FullName() string {
+	switch {
+	case d.Name == "":
+		return d.String()
+	case d.Number > 0:
+		return d.String() + "/" + d.Name
+	default:
+		return d.String() + " (" + d.Name + ")"
+	}
+}
+
+// Packed упакованная форма MPC: "0001P", "0073Pb", "CK20F030", "CK19Y04b".
+// Двухбуквенные фрагменты в упакованной форме не представимы — тогда возвращается пустая строка.
+func (d Designation) Packed() string {
+	if len(d.Fragment) > 1 {
+		return ""
+	}
+	fragment := strings.ToLower(d.Fragment)
+
+	if d.Number > 0 {
+		if d.Number > 9999 {
+			return ""
+		}
+		return fmt.Sprintf("%04d%c%s", d.Number, d.Type, fragment)
+	}
+
+	century := d.Year / 100
+	if century < 18 || century > 20 || d.Order > 619 {
+		return ""
+	}
+	if fragment == "" {
+		fragment = "0"
+	}
+	return fmt.Sprintf("%c%c%02d%c%c%d%s", d.Type, 'I'+byte(century-18), d.Year%100, d.HalfMonth, packedDigit(d.Order/10), d.Order%10, fragment)
+}
+
+func parsePacked(m []string, name string) (Designation, error) {
+	century := 18 + int(m[2][0]-'I')
+	yy, _ := strconv.Atoi(m[3])
+	tens, ok := unpackDigit(m[5][0])
+	if !ok {
+		return Designation{}, fmt.Errorf("%w: %q", ErrInvalid, m[0])
+	}
+	units := int(m[6][0] - '0')
+
+	d := Designation{
+		Type:      m[1][0],
+		Year:      century*100 + yy,
+		HalfMonth: m[4][0],
+		Order:     tens*10 + units,
+		Name:      name,
+	}
+	if m[7] != "0" {
+		d.Fragment = strings.ToUpper(m[7])
+	}
+	return d, d.validateProvisional(m[0])
+}
+
+func (d Designation) validateProvisional(s string) error {
+	if d.Order == 0 {
+		return fmt.Errorf("%w: %q has zero discovery order", ErrInvalid, s)
+	}
+	if d.Year < 1000 || d.Year > 2999 {
+		return fmt.Errorf("%w: %q has year out of range", ErrInvalid, s)
+	}
+	return nil
+}
+
+// packedDigit кодирует число 0–61 одним символом: 0–9, A–Z, a–z
+func packedDigit(v int) byte {
+	switch {
+	case v < 10:
+		return byte('0' + v)
+	case v < 36:
+		return byte('A' + v - 10)
+	default:
+		return byte('a' + v - 36)
+	}
+}
+
+func unpackDigit(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10, true
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 36, true
+	default:
+		return 0, false
+	}
+}
+
+// originalCase возвращает фрагмент upper из исходной строки с исходным регистром
+func originalCase(text, upper string) string {
+	if i := strings.LastIndex(strings.ToUpper(text), upper); i >= 0 {
+		return strings.TrimSpace(text[i : i+len(upper)])
+	}
+	return strings.TrimSpace(upper)
+}
@@ -0,0 +1,99 @@
+package designation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeEquivalentForms(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"C/2020 F3", "C/2020 F3"},
+		{"c2020f3", "C/2020 F3"},
+		{"C/2020 F3 (NEOWISE)", "C/2020 F3"},
+		{"CK20F030", "C/2020 F3"},
+		{"73P-B", "73P-B"},
+		{"73PB", "73P-B"},
+		{"0073Pb", "73P-B"},
+		{"1P/Halley", "1P"},
+		{"0001P", "1P"},
+		{"C/2019 Y4-B", "C/2019 Y4-B"},
+		{"CK19Y04b", "C/2019 Y4-B"},
+		{"P/2010 A2", "P/2010 A2"},
+		{"2I/Borisov", "2I"},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.input)
+		if err != nil {
+			t.Errorf("Normalize(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		input    string
+		name     string
+		fullName string
+	}{
+		{"C/2020 F3 (NEOWISE)", "NEOWISE", "C/2020 F3 (NEOWISE)"},
+		{"1P/Halley", "Halley", "1P/Halley"},
+		{"c2020f3", "", "C/2020 F3"},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.input, err)
+			continue
+		}
+		if d.Name != tt.name || d.FullName() != tt.fullName {
+			t.Errorf("Parse(%q) name %q, full name %q; want %q, %q", tt.input, d.Name, d.FullName(), tt.name, tt.fullName)
+		}
+	}
+}
+
+func TestPacked(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1P", "0001P"},
+		{"73P-B", "0073Pb"},
+		{"C/2020 F3", "CK20F030"},
+		{"C/2019 Y4-B", "CK19Y04b"},
+		{"C/1995 O1", "CJ95O010"},
+		{"73P-AA", ""},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got := d.Packed(); got != tt.want {
+			t.Errorf("Parse(%q).Packed() = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"Halley",
+		"C/2020 I3",
+		"C/2020 F0",
+		"0P",
+		"Q/2020 F3",
+		"CK20F000",
+	} {
+		if _, err := Parse(input); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalid", input, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package ical формирует календари в формате iCalendar (RFC 5545)
+// для подписки из обычных календарных приложений.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const maxLineOctets = 75
+
+// Event событие календаря на целый день
+type Event struct {
+	UID         string
+	Date        time.Time // Учитывается только календарная дата в UTC
+	Summary     string
+	Description string
+}
+
+// Calendar набор событий под общим названием
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Marshal сериализует календарь; stamp записывается в DTSTAMP всех событий
+func (c Calendar) Marshal(stamp time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(s string) {
+		buf.WriteString(fold(s))
+		buf.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//cargo-comet//comet events//RU")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	for _, e := range c.Events {
+		day := e.Date.UTC()
+		line("BEGIN:VEVENT")
+		line("UID:" + escape(e.UID))
+		line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escape(e.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escape экранирует спецсимволы текстовых значений
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold переносит строки длиннее 75 октетов, не разрывая символы UTF-8
+func fold(s string) string {
+	if len(s) <= maxLineOctets {
+		return s
+	}
+	var b strings.Builder
+	limit := maxLineOctets
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 0
+			limit = maxLineOctets - 1 // Пробел продолжения входит в длину строки
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+// Package netguard не дает сервису обращаться по адресам, заданным пользователями
+// (webhook и т. п.), к внутренним узлам: loopback, частным и link-local сетям,
+// служебным диапазонам и сервисам метаданных облака.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress адрес недоступен для исходящих запросов
+var ErrForbiddenAddress = errors.New("address is not allowed")
+
+// Диапазоны, не покрытые методами net.IP: CGNAT, служебные и зарезервированные сети IANA
+var reservedNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",
+		"100.64.0.0/10",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"240.0.0.0/4",
+		"64:ff9b::/96", // NAT64 ведет в IPv4, в том числе во внутренние сети
+		"2001:db8::/32",
+	} {
+		_, n, _ := net.ParseCIDR(cidr)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// IsPublic сообщает, является ли адрес публичным адресом в интернете
+func IsPublic(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range reservedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Guard проверяет адреса исходящих запросов. Если AllowedHosts не пуст, разрешены
+// только перечисленные узлы ("hooks.example.com") и их поддомены (".example.com").
+// Публичность адреса проверяется всегда.
+type Guard struct {
+	AllowedHosts []string
+}
+
+// FromEnv список разрешенных узлов из WEBHOOK_ALLOWED_HOSTS (через запятую)
+func FromEnv() *Guard {
+	g := &Guard{}
+	for _, host := range strings.Split(os.Getenv("WEBHOOK_ALLOWED_HOSTS"), ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			g.AllowedHosts = append(g.AllowedHosts, host)
+		}
+	}
+	return g
+}
+
+// HostAllowed сообщает, входит ли узел в список разрешенных
+func (g *Guard) HostAllowed(host string) bool {
+	if len(g.AllowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range g.AllowedHosts {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL проверяет абсолютный http(s) адрес: узел разрешен и все его адреса публичные
+func (g *Guard) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: must be an absolute http(s) URL", ErrForbiddenAddress)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in URL are not supported", ErrForbiddenAddress)
+	}
+
+	host := u.Hostname()
+	if !g.HostAllowed(host) {
+		return fmt.Errorf("%w: host %s is not in the allow-list", ErrForbiddenAddress, host)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: host %s cannot be resolved", ErrForbiddenAddress, host)
+	}
+	for _, addr := range addrs {
+		if !IsPublic(addr.IP) {
+			return fmt.Errorf("%w: host %s resolves to non-public address %s", ErrForbiddenAddress, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// Control для net.Dialer: запрещает соединение с непубличным адресом уже после
+// разрешения имени, поэтому подмена DNS между проверкой и запросом не помогает
+func (g *Guard) Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
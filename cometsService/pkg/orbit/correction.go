@@ -0,0 +1,153 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrTooFewObservations для оценки ковариации нужно больше уравнений, чем неизвестных
+var ErrTooFewObservations = errors.New("differential correction needs at least 4 observations")
+
+// KeplerianNames порядок кеплеровских элементов в KeplerianCovariance
+var KeplerianNames = [6]string{
+	"semi_major_axis",
+	"eccentricity",
+	"inclination_deg",
+	"raan_deg",
+	"argument_of_perihelion",
+	"true_anomaly_deg",
+}
+
+// Correction результат дифференциального уточнения орбиты
+type Correction struct {
+	JD         float64  // Эпоха уточненной орбиты
+	State      State    // Уточненный вектор состояния на эпоху
+	Elements   Elements // Уточненные перигелийные элементы
+	Converged  bool
+	Iterations int
+	InitialRMS float64 // Невязка исходной орбиты, угл. сек
+	RMS        float64 // Невязка уточненной орбиты, угл. сек
+	// Ковариация вектора состояния (а.е., а.е./сут), масштабированная по невязкам
+	Covariance [6][6]float64
+}
+
+// DifferentialCorrection уточняет орбиту по всем наблюдениям итерационным методом
+// наименьших квадратов. Неизвестными служат компоненты вектора состояния на эпоху jd,
+// что одинаково подходит для эллиптических, параболических и гиперболических орбит.
+func DifferentialCorrection(initial Elements, jd float64, obs []AngleObservation, maxIter int) (*Correction, error) {
+	if len(obs) < 4 {
+		return nil, ErrTooFewObservations
+	}
+
+	start := initial.StateAt(jd)
+	initialRes, ok := stateResiduals(start, jd, obs)
+	if !ok {
+		return nil, ErrInvalidElements
+	}
+
+	state, cost, iterations, converged := fitState(start, jd, obs, maxIter)
+	el, err := ElementsFromState(state, jd)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Correction{
+		JD:         jd,
+		State:      state,
+		Elements:   el,
+		Converged:  converged,
+		Iterations: iterations,
+		InitialRMS: rmsArcsec(sumSquares(initialRes), len(obs)),
+		RMS:        rmsArcsec(cost, len(obs)),
+	}
+
+	// Ковариация σ²(JᵀJ)⁻¹, σ² оценивается по остаточной сумме квадратов
+	res, ok := stateResiduals(state, jd, obs)
+	if !ok {
+		return result, nil
+	}
+	jac, ok := stateJacobian(state, jd, obs, res)
+	if !ok {
+		return result, nil
+	}
+	n, _ := normalEquations(jac, res)
+	if inv, ok := invert6(n); ok {
+		sigma2 := cost / float64(len(res)-6)
+		for i := range 6 {
+			for j := range 6 {
+				result.Covariance[i][j] = sigma2 * inv[i][j]
+			}
+		}
+	}
+	return result, nil
+}
+
+// KeplerianCovariance переводит ковариацию вектора состояния в ковариацию кеплеровских
+// элементов в порядке KeplerianNames (углы в градусах)
+func (c *Correction) KeplerianCovariance() ([6][6]float64, error) {
+	base, err := keplerian(c.State, c.JD)
+	if err != nil {
+		return [6][6]float64{}, err
+	}
+
+	// Численная матрица производных элементов по вектору состояния
+	var g [6][6]float64
+	for j := range 6 {
+		h := 1e-8 * math.Max(Norm(c.State.Position), 1)
+		if j >= 3 {
+			h = 1e-8 * math.Max(Norm(c.State.Velocity), 1e-3)
+		}
+		shifted := applyStep(c.State, unitStep(j, h))
+		values, err := keplerian(shifted, c.JD)
+		if err != nil {
+			return [6][6]float64{}, err
+		}
+		for i := range 6 {
+			d := values[i] - base[i]
+			if i >= 2 {
+				d = math.Remainder(d, 360)
+			}
+			g[i][j] = d / h
+		}
+	}
+
+	// G C Gᵀ
+	var result [6][6]float64
+	for i := range 6 {
+		for k := range 6 {
+			var sum float64
+			for a := range 6 {
+				for b := range 6 {
+					sum += g[i][a] * c.Covariance[a][b] * g[k][b]
+				}
+			}
+			result[i][k] = sum
+		}
+	}
+	return result, nil
+}
+
+// keplerian кеплеровские элементы (a, e, i, Ω, ω, ν) вектора состояния
+func keplerian(s State, jd float64) ([6]float64, error) {
+	el, err := ElementsFromState(s, jd)
+	if err != nil {
+		return [6]float64{}, err
+	}
+	a, err := el.SemiMajorAxis()
+	if err != nil {
+		return [6]float64{}, err
+	}
+	nu, _ := el.TrueAnomaly(jd)
+	return [6]float64{a, el.E, el.I, el.Node, el.Peri, nu}, nil
+}
+
+func unitStep(i int, h float64) [6]float64 {
+	var dx [6]float64
+	dx[i] = h
+	return dx
+}
+
+// rmsArcsec среднеквадратичная угловая невязка на наблюдение, угл. сек
+func rmsArcsec(cost float64, count int) float64 {
+	return math.Sqrt(cost/float64(count)) / deg * 3600
+}
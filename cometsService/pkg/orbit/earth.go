@@ -0,0 +1,78 @@
+package orbit
+
+import "math"
+
+// JDJ2000 юлианская дата эпохи J2000.0
+const JDJ2000 = 2451545.0
+
+// EarthElements средние элементы барицентра Земля–Луна на момент jd
+// (Standish, "Keplerian Elements for Approximate Positions of the Major Planets", 1800–2050).
+func EarthElements(jd float64) Elements {
+	return planetElements(jd, earthMeanElements)
+}
+
+// EarthPositionError оценка наибольшей ошибки положения Земли по средним элементам
+// Standish на 1800–2050 годы (20″ по долготе и 6000 км по расстоянию), а.е.
+const EarthPositionError = 1e-4
+
+// EarthState гелиоцентрический вектор состояния барицентра Земля–Луна на момент jd.
+// Для направлений на близкие тела нужен центр Земли, см. EarthPosition.
+func EarthState(jd float64) State {
+	return EarthElements(jd).StateAt(jd)
+}
+
+// EarthPosition гелиоцентрическое положение центра Земли на момент jd: барицентр
+// Земля–Луна смещен на долю массы Луны от направления на Луну (около 4700 км).
+// Без этой поправки направление на тело в 0.1 а.е. ошибается до минуты дуги.
+func EarthPosition(jd float64) [3]float64 {
+	return Sub(EarthState(jd).Position, Scale(MoonPosition(jd), moonMassFraction))
+}
+
+// meanElements средние элементы планеты и их вековые изменения (на юлианское столетие)
+type meanElements struct {
+	A, E, I, L, LongPeri, Node                   float64
+	ADot, EDot, IDot, LDot, LongPeriDot, NodeDot float64
+}
+
+var earthMeanElements = meanElements{
+	A: 1.00000261, E: 0.01671123, I: -0.00001531, L: 100.46457166, LongPeri: 102.93768193, Node: 0,
+	ADot: 0.00000562, EDot: -0.00004392, IDot: -0.01294668, LDot: 35999.37244981, LongPeriDot: 0.32327364, NodeDot: 0,
+}
+
+func planetElements(jd float64, m meanElements) Elements {
+	t := (jd - JDJ2000) / 36525
+
+	a := m.A + m.ADot*t
+	e := m.E + m.EDot*t
+	i := m.I + m.IDot*t
+	l := m.L + m.LDot*t
+	longPeri := m.LongPeri + m.LongPeriDot*t
+	node := m.Node + m.NodeDot*t
+
+	// Отрицательное наклонение эквивалентно повороту узла на 180°
+	if i < 0 {
+		i = -i
+		node += 180
+	}
+
+	meanAnomaly := math.Remainder((l-longPeri)*deg, 2*math.Pi)
+	n := GaussK / math.Pow(a, 1.5)
+
+	return Elements{
+		Q:    a * (1 - e),
+		E:    e,
+		I:    i,
+		Node: normalizeDeg(node),
+		Peri: normalizeDeg(longPeri - node),
+		Tp:   jd - meanAnomaly/n,
+	}
+}
+
+// normalizeDeg приводит угол к диапазону [0, 360)
+func normalizeDeg(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
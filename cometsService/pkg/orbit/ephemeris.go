@@ -0,0 +1,40 @@
+package orbit
+
+import "math"
+
+// SpeedOfLight скорость света, а.е./сут
+const SpeedOfLight = 173.1446326846693
+
+// Ephemeris геоцентрическое положение тела на момент наблюдения
+type Ephemeris struct {
+	RA    float64 // Прямое восхождение (J2000), градусы
+	Dec   float64 // Склонение (J2000), градусы
+	Delta float64 // Геоцентрическое расстояние, а.е.
+	R     float64 // Гелиоцентрическое расстояние, а.е.
+	Phase float64 // Фазовый угол Солнце–тело–наблюдатель, градусы
+}
+
+// EphemerisAt вычисляет астрометрическое геоцентрическое положение на момент jd
+// с учетом светового времени
+func (el Elements) EphemerisAt(jd float64) Ephemeris {
+	return el.TopocentricEphemerisAt(jd, [3]float64{})
+}
+
+// TopocentricEphemerisAt то же, что EphemerisAt, для наблюдателя, смещенного
+// от центра Земли на вектор observer (экваториальный J2000, а.е.)
+func (el Elements) TopocentricEphemerisAt(jd float64, observer [3]float64) Ephemeris {
+	earth := Add(EarthPosition(jd), EquatorialToEcliptic(observer))
+
+	tau := 0.0
+	var body, rho [3]float64
+	for range 3 {
+		body = el.PositionAt(jd - tau)
+		rho = Sub(body, earth)
+		tau = Norm(rho) / SpeedOfLight
+	}
+
+	ra, dec := RaDec(EclipticToEquatorial(rho))
+	delta, r := Norm(rho), Norm(body)
+	phase := math.Acos(clamp(Dot(body, rho)/(r*delta), -1, 1)) / deg
+	return Ephemeris{RA: ra, Dec: dec, Delta: delta, R: r, Phase: phase}
+}
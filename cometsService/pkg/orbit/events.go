@@ -0,0 +1,125 @@
+package orbit
+
+import (
+	"math"
+	"sort"
+)
+
+// EventKind тип события на орбите кометы
+type EventKind string
+
+const (
+	EventPerihelion    EventKind = "perihelion"
+	EventCloseApproach EventKind = "close_approach"
+	EventConjunction   EventKind = "conjunction"
+	EventOpposition    EventKind = "opposition"
+)
+
+// Шаг поиска экстремумов и смен знака, сутки
+const eventScanStep = 1.0
+
+// Event событие на орбите кометы
+type Event struct {
+	Kind       EventKind
+	JD         float64
+	R          float64 // Гелиоцентрическое расстояние, а.е.
+	Delta      float64 // Геоцентрическое расстояние, а.е.
+	Elongation float64 // Угловое расстояние от Солнца, градусы
+}
+
+// FindEvents находит прохождения перигелия, минимумы расстояния до Земли,
+// соединения и противостояния с Солнцем (по эклиптической долготе) на интервале [fromJD, toJD]
+func FindEvents(el Elements, fromJD, toJD float64) []Event {
+	var events []Event
+	for _, jd := range el.perihelionDates(fromJD, toJD) {
+		events = append(events, Event{Kind: EventPerihelion, JD: jd})
+	}
+
+	delta := func(jd float64) float64 { return el.EphemerisAt(jd).Delta }
+	// Синус разности геоцентрических эклиптических долгот кометы и Солнца
+	longitudeDiff := func(jd float64) float64 {
+		earth := EarthPosition(jd)
+		rho := Sub(el.PositionAt(jd), earth)
+		return math.Atan2(rho[1], rho[0]) - math.Atan2(-earth[1], -earth[0])
+	}
+	sinDiff := func(jd float64) float64 { return math.Sin(longitudeDiff(jd)) }
+
+	steps := int(math.Ceil((toJD - fromJD) / eventScanStep))
+	prevJD := fromJD
+	prevDelta, prevSin := delta(fromJD), sinDiff(fromJD)
+	approaching := false
+	for i := 1; i <= steps; i++ {
+		jd := math.Min(fromJD+float64(i)*eventScanStep, toJD)
+		d, s := delta(jd), sinDiff(jd)
+
+		// Минимум расстояния лежит между двумя предыдущими шагами
+		if d < prevDelta {
+			approaching = true
+		} else if approaching {
+			events = append(events, Event{Kind: EventCloseApproach, JD: goldenMinimum(delta, prevJD-eventScanStep, jd)})
+			approaching = false
+		}
+
+		if prevSin == 0 || prevSin*s < 0 {
+			root := bisect(sinDiff, prevJD, jd)
+			kind := EventOpposition
+			if math.Cos(longitudeDiff(root)) > 0 {
+				kind = EventConjunction
+			}
+			events = append(events, Event{Kind: kind, JD: root})
+		}
+		prevJD, prevDelta, prevSin = jd, d, s
+	}
+
+	for i := range events {
+		e := &events[i]
+		eph := el.EphemerisAt(e.JD)
+		e.R, e.Delta = eph.R, eph.Delta
+		e.Elongation = SolarElongation(el, e.JD)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].JD < events[j].JD })
+	return events
+}
+
+// SolarElongation геоцентрическое угловое расстояние тела от Солнца на момент jd, градусы
+func SolarElongation(el Elements, jd float64) float64 {
+	earth := EarthPosition(jd)
+	sun := Scale(earth, -1)
+	rho := Sub(el.PositionAt(jd), earth)
+	return math.Acos(clamp(Dot(rho, sun)/(Norm(rho)*Norm(sun)), -1, 1)) / deg
+}
+
+// perihelionDates моменты прохождения перигелия на интервале [fromJD, toJD]
+func (el Elements) perihelionDates(fromJD, toJD float64) []float64 {
+	if el.E >= 1 {
+		if el.Tp >= fromJD && el.Tp <= toJD {
+			return []float64{el.Tp}
+		}
+		return nil
+	}
+	period := el.Period()
+	var dates []float64
+	for jd := el.Tp + math.Ceil((fromJD-el.Tp)/period)*period; jd <= toJD; jd += period {
+		dates = append(dates, jd)
+	}
+	return dates
+}
+
+// goldenMinimum минимум унимодальной функции на отрезке [lo, hi] методом золотого сечения
+func goldenMinimum(f func(float64) float64, lo, hi float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+	x1, x2 := hi-ratio*(hi-lo), lo+ratio*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for hi-lo > 1e-5 {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - ratio*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + ratio*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	return (lo + hi) / 2
+}
@@ -0,0 +1,343 @@
+package orbit
+
+import (
+	"math"
+	"sort"
+)
+
+// stateResiduals невязки наблюдений (ΔRA·cos δ, Δδ в радианах) для орбиты,
+// заданной вектором состояния на момент jd
+func stateResiduals(s State, jd float64, obs []AngleObservation) ([]float64, bool) {
+	el, err := ElementsFromState(s, jd)
+	if err != nil {
+		return nil, false
+	}
+
+	res := make([]float64, 0, 2*len(obs))
+	for _, o := range obs {
+		eph := el.TopocentricEphemerisAt(o.JD, o.Observer)
+		if math.IsNaN(eph.RA) || math.IsNaN(eph.Dec) {
+			return nil, false
+		}
+		res = append(res,
+			math.Remainder(o.RA-eph.RA, 360)*deg*math.Cos(o.Dec*deg),
+			(o.Dec-eph.Dec)*deg,
+		)
+	}
+	return res, true
+}
+
+// fitState подгоняет вектор состояния к наблюдениям методом Левенберга — Марквардта
+// с численными частными производными. Возвращает уточненное состояние, сумму
+// квадратов невязок, число выполненных итераций и признак сходимости.
+func fitState(initial State, jd float64, obs []AngleObservation, maxIter int) (State, float64, int, bool) {
+	state := initial
+	res, ok := stateResiduals(state, jd, obs)
+	if !ok {
+		return initial, math.Inf(1), 0, false
+	}
+	cost := sumSquares(res)
+	lambda := 1e-3
+
+	for iter := 1; iter <= maxIter; iter++ {
+		jac, ok := stateJacobian(state, jd, obs, res)
+		if !ok {
+			return state, cost, iter, false
+		}
+
+		// Нормальные уравнения (JᵀJ + λ·diag) dx = Jᵀr
+		n, b := normalEquations(jac, res)
+
+		improved := false
+		for range 10 {
+			damped := n
+			for i := range 6 {
+				damped[i][i] *= 1 + lambda
+			}
+			dx, ok := solve6(damped, b)
+			if !ok {
+				lambda *= 10
+				continue
+			}
+
+			candidate := applyStep(state, dx)
+			candidateRes, ok := stateResiduals(candidate, jd, obs)
+			if ok {
+				if candidateCost := sumSquares(candidateRes); candidateCost < cost {
+					converged := cost-candidateCost < 1e-12*cost || candidateCost < 1e-24
+					state, res, cost = candidate, candidateRes, candidateCost
+					lambda = math.Max(lambda/10, 1e-12)
+					improved = true
+					if converged {
+						return state, cost, iter, true
+					}
+					break
+				}
+			}
+			lambda *= 10
+		}
+		if !improved {
+			// Шаг не уменьшает невязку: достигнут минимум с точностью вычислений
+			return state, cost, iter, true
+		}
+	}
+	return state, cost, maxIter, false
+}
+
+// normalEquations матрица JᵀJ и вектор Jᵀr
+func normalEquations(jac [][6]float64, res []float64) ([6][6]float64, [6]float64) {
+	var n [6][6]float64
+	var b [6]float64
+	for k := range res {
+		for i := range 6 {
+			b[i] += jac[k][i] * res[k]
+			for j := range 6 {
+				n[i][j] += jac[k][i] * jac[k][j]
+			}
+		}
+	}
+	return n, b
+}
+
+// stateJacobian численные производные невязок по компонентам вектора состояния
+func stateJacobian(s State, jd float64, obs []AngleObservation, base []float64) ([][6]float64, bool) {
+	jac := make([][6]float64, len(base))
+	for i := range 6 {
+		h := 1e-7 * math.Max(Norm(s.Position), 1)
+		if i >= 3 {
+			h = 1e-7 * math.Max(Norm(s.Velocity), 1e-3)
+		}
+
+		shifted := s
+		if i < 3 {
+			shifted.Position[i] += h
+		} else {
+			shifted.Velocity[i-3] += h
+		}
+		res, ok := stateResiduals(shifted, jd, obs)
+		if !ok {
+			return nil, false
+		}
+
+		// Невязка O-C уменьшается на величину изменения модели
+		for k := range base {
+			jac[k][i] = (base[k] - res[k]) / h
+		}
+	}
+	return jac, true
+}
+
+func applyStep(s State, dx [6]float64) State {
+	for i := range 3 {
+		s.Position[i] += dx[i]
+		s.Velocity[i] += dx[i+3]
+	}
+	return s
+}
+
+func sumSquares(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return sum
+}
+
+// invert6 обращает матрицу 6x6 решением систем для столбцов единичной матрицы
+func invert6(a [6][6]float64) ([6][6]float64, bool) {
+	var inv [6][6]float64
+	for col := range 6 {
+		var e [6]float64
+		e[col] = 1
+		x, ok := solve6(a, e)
+		if !ok {
+			return inv, false
+		}
+		for row := range 6 {
+			inv[row][col] = x[row]
+		}
+	}
+	return inv, true
+}
+
+// solve6 решает систему 6x6 методом Гаусса с выбором главного элемента
+func solve6(a [6][6]float64, b [6]float64) ([6]float64, bool) {
+	for col := range 6 {
+		pivot := col
+		for row := col + 1; row < 6; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if a[pivot][col] == 0 || math.IsNaN(a[pivot][col]) {
+			return [6]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < 6; row++ {
+			k := a[row][col] / a[col][col]
+			for j := col; j < 6; j++ {
+				a[row][j] -= k * a[col][j]
+			}
+			b[row] -= k * b[col]
+		}
+	}
+
+	var x [6]float64
+	for i := 5; i >= 0; i-- {
+		sum := b[i]
+		for j := i + 1; j < 6; j++ {
+			sum -= a[i][j] * x[j]
+		}
+		x[i] = sum / a[i][i]
+	}
+	return x, true
+}
+
+// fitParams подгоняет произвольный набор параметров методом Левенберга — Марквардта
+// с численными производными. Используется методами, в которых орбита задается
+// меньшим числом неизвестных, чем полный вектор состояния.
+func fitParams(initial []float64, residuals func([]float64) ([]float64, bool), maxIter int) ([]float64, float64, bool) {
+	n := len(initial)
+	x := append([]float64(nil), initial...)
+	res, ok := residuals(x)
+	if !ok {
+		return x, math.Inf(1), false
+	}
+	cost := sumSquares(res)
+	lambda := 1e-3
+
+	for range maxIter {
+		jac := make([][]float64, n)
+		for i := range n {
+			h := 1e-7 * math.Max(math.Abs(x[i]), 1)
+			shifted := append([]float64(nil), x...)
+			shifted[i] += h
+			r, ok := residuals(shifted)
+			if !ok {
+				return x, cost, false
+			}
+			jac[i] = make([]float64, len(res))
+			for k := range res {
+				jac[i][k] = (res[k] - r[k]) / h
+			}
+		}
+
+		normal := make([][]float64, n)
+		b := make([]float64, n)
+		for i := range n {
+			normal[i] = make([]float64, n)
+			for k := range res {
+				b[i] += jac[i][k] * res[k]
+			}
+			for j := range n {
+				for k := range res {
+					normal[i][j] += jac[i][k] * jac[j][k]
+				}
+			}
+		}
+
+		improved := false
+		for range 10 {
+			damped := make([][]float64, n)
+			for i := range n {
+				damped[i] = append([]float64(nil), normal[i]...)
+				damped[i][i] *= 1 + lambda
+			}
+			dx, ok := solveN(damped, append([]float64(nil), b...))
+			if !ok {
+				lambda *= 10
+				continue
+			}
+
+			candidate := make([]float64, n)
+			for i := range n {
+				candidate[i] = x[i] + dx[i]
+			}
+			candidateRes, ok := residuals(candidate)
+			if ok {
+				if candidateCost := sumSquares(candidateRes); candidateCost < cost {
+					converged := cost-candidateCost < 1e-12*cost || candidateCost < 1e-24
+					x, res, cost = candidate, candidateRes, candidateCost
+					lambda = math.Max(lambda/10, 1e-12)
+					improved = true
+					if converged {
+						return x, cost, true
+					}
+					break
+				}
+			}
+			lambda *= 10
+		}
+		if !improved {
+			return x, cost, true
+		}
+	}
+	return x, cost, false
+}
+
+// solveN решает систему произвольного размера методом Гаусса с выбором главного
+// элемента; матрица и правая часть изменяются
+func solveN(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if a[pivot][col] == 0 || math.IsNaN(a[pivot][col]) {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			k := a[row][col] / a[col][col]
+			for j := col; j < n; j++ {
+				a[row][j] -= k * a[col][j]
+			}
+			b[row] -= k * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i][j] * x[j]
+		}
+		x[i] = sum / a[i][i]
+	}
+	return x, true
+}
+
+// fitFromGrid вычисляет невязки во всех узлах сетки начальных приближений и
+// подгоняет параметры из нескольких лучших узлов; возвращает лучший результат
+func fitFromGrid(grid [][]float64, residuals func([]float64) ([]float64, bool), starts, maxIter int) ([]float64, float64, bool) {
+	type node struct {
+		x    []float64
+		cost float64
+	}
+	var nodes []node
+	for _, x := range grid {
+		if res, ok := residuals(x); ok {
+			nodes = append(nodes, node{x: x, cost: sumSquares(res)})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].cost < nodes[j].cost })
+	if len(nodes) > starts {
+		nodes = nodes[:starts]
+	}
+
+	var best []float64
+	bestCost := math.Inf(1)
+	for _, n := range nodes {
+		if x, cost, _ := fitParams(n.x, residuals, maxIter); cost < bestCost {
+			best, bestCost = x, cost
+		}
+	}
+	return best, bestCost, best != nil
+}
@@ -0,0 +1,62 @@
+package orbit
+
+import "math"
+
+const (
+	// ObliquityJ2000 наклон эклиптики к экватору на эпоху J2000, градусы
+	ObliquityJ2000 = 23.4392911
+	// AUPerDayToKmPerSec перевод скорости из а.е./сут в км/с
+	AUPerDayToKmPerSec = 149597870.7 / 86400
+)
+
+// EclipticToEquatorial поворачивает вектор из эклиптики J2000 в экваториальную систему J2000
+func EclipticToEquatorial(v [3]float64) [3]float64 {
+	c, s := math.Cos(ObliquityJ2000*deg), math.Sin(ObliquityJ2000*deg)
+	return [3]float64{v[0], c*v[1] - s*v[2], s*v[1] + c*v[2]}
+}
+
+// EquatorialToEcliptic обратный поворот к EclipticToEquatorial
+func EquatorialToEcliptic(v [3]float64) [3]float64 {
+	c, s := math.Cos(ObliquityJ2000*deg), math.Sin(ObliquityJ2000*deg)
+	return [3]float64{v[0], c*v[1] + s*v[2], -s*v[1] + c*v[2]}
+}
+
+// RaDec возвращает прямое восхождение [0, 360) и склонение вектора в градусах
+func RaDec(v [3]float64) (float64, float64) {
+	ra := math.Atan2(v[1], v[0]) / deg
+	dec := math.Atan2(v[2], math.Hypot(v[0], v[1])) / deg
+	return normalizeDeg(ra), dec
+}
+
+// UnitVector единичный вектор направления по прямому восхождению и склонению (градусы)
+func UnitVector(raDeg, decDeg float64) [3]float64 {
+	ra, dec := raDeg*deg, decDeg*deg
+	return [3]float64{math.Cos(dec) * math.Cos(ra), math.Cos(dec) * math.Sin(ra), math.Sin(dec)}
+}
+
+// AngularSeparation угловое расстояние между двумя направлениями, градусы
+func AngularSeparation(ra1, dec1, ra2, dec2 float64) float64 {
+	a, b := UnitVector(ra1, dec1), UnitVector(ra2, dec2)
+	cross := [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+	return math.Atan2(Norm(cross), Dot(a, b)) / deg
+}
+
+// Add сумма векторов
+func Add(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+// Sub разность векторов a - b
+func Sub(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+// Dot скалярное произведение
+func Dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+// Norm длина вектора
+func Norm(v [3]float64) float64 {
+	return math.Sqrt(Dot(v, v))
+}
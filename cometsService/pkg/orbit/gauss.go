@@ -0,0 +1,227 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNoGaussSolution метод Гаусса не дал физически допустимого решения
+var ErrNoGaussSolution = errors.New("gauss method found no admissible orbit")
+
+// minGaussDistance минимальное допустимое геоцентрическое расстояние решения, а.е.
+const minGaussDistance = 0.01
+
+// AngleObservation астрометрическое наблюдение для предварительного определения орбиты
+type AngleObservation struct {
+	JD       float64
+	RA       float64    // Прямое восхождение (J2000), градусы
+	Dec      float64    // Склонение (J2000), градусы
+	Observer [3]float64 // Смещение наблюдателя от центра Земли (экваториальное J2000, а.е.)
+}
+
+// GaussSolution одно из решений метода Гаусса
+type GaussSolution struct {
+	JD    float64 // Эпоха вектора состояния с поправкой за световое время
+	State State   // Гелиоцентрический эклиптический вектор состояния
+}
+
+// Gauss определяет предварительную орбиту по трем наблюдениям методом Гаусса
+// с итерационным уточнением коэффициентов Лагранжа и поправкой за световое время.
+// Полученное решение доуточняется методом Ньютона по тем же трем наблюдениям.
+// Уравнение Лагранжа может иметь несколько корней, поэтому возвращаются все
+// допустимые решения; выбирать между ними следует по остальным наблюдениям.
+func Gauss(obs [3]AngleObservation) ([]GaussSolution, error) {
+	if !(obs[0].JD < obs[1].JD && obs[1].JD < obs[2].JD) {
+		return nil, ErrInvalidElements
+	}
+
+	var l, sites [3][3]float64
+	for i, o := range obs {
+		l[i] = UnitVector(o.RA, o.Dec)
+		sites[i] = Add(EclipticToEquatorial(EarthPosition(o.JD)), o.Observer)
+	}
+
+	p := [3][3]float64{Cross(l[1], l[2]), Cross(l[0], l[2]), Cross(l[0], l[1])}
+	d0 := Dot(l[0], p[0])
+	if math.Abs(d0) < 1e-14 {
+		// Три направления лежат в одной плоскости
+		return nil, ErrNoGaussSolution
+	}
+	var d [3][3]float64
+	for i := range 3 {
+		for j := range 3 {
+			d[i][j] = Dot(sites[i], p[j])
+		}
+	}
+
+	tau1 := obs[0].JD - obs[1].JD
+	tau3 := obs[2].JD - obs[1].JD
+	tau := tau3 - tau1
+
+	a := (-d[0][1]*tau3/tau + d[1][1] + d[2][1]*tau1/tau) / d0
+	b := (d[0][1]*(tau3*tau3-tau*tau)*tau3/tau + d[2][1]*(tau*tau-tau1*tau1)*tau1/tau) / (6 * d0)
+	e := Dot(sites[1], l[1])
+	r2sq := Dot(sites[1], sites[1])
+
+	// Уравнение Лагранжа r^8 + c6 r^6 + c3 r^3 + c0 = 0
+	c6 := -(a*a + 2*a*e + r2sq)
+	c3 := -2 * MuSun * b * (a + e)
+	c0 := -MuSun * MuSun * b * b
+	poly := func(x float64) float64 {
+		x3 := x * x * x
+		return x3*x3*x*x + c6*x3*x3 + c3*x3 + c0
+	}
+
+	var solutions []GaussSolution
+	for _, r2 := range positiveRoots(poly, 0.01, 200) {
+		rho2 := a + MuSun*b/(r2*r2*r2)
+		if rho2 <= 0 {
+			continue
+		}
+		s, ok := refineGauss(obs, l, sites, d, d0, r2)
+		if !ok {
+			continue
+		}
+		// Итерации Гаусса сходятся не всегда, поэтому решение доводится
+		// до точного прохождения через три наблюдения
+		if polished, _, _, ok := fitState(s.State, s.JD, obs[:], 50); ok {
+			s.State = polished
+		}
+
+		// Вырожденное решение у самой Земли формально проходит через любые направления
+		if Norm(Sub(s.State.Position, EarthPosition(s.JD))) < minGaussDistance {
+			continue
+		}
+		solutions = append(solutions, s)
+	}
+
+	if len(solutions) == 0 {
+		return nil, ErrNoGaussSolution
+	}
+	return solutions, nil
+}
+
+// refineGauss строит решение по корню r2 и уточняет его с точными f и g.
+// На длинных дугах и вблизи перигелия итерации могут расходиться; тогда
+// возвращается последнее приближение, после которого поправки начали расти.
+func refineGauss(obs [3]AngleObservation, l, sites, d [3][3]float64, d0, r2 float64) (GaussSolution, bool) {
+	tau1 := obs[0].JD - obs[1].JD
+	tau3 := obs[2].JD - obs[1].JD
+	u := MuSun / (r2 * r2 * r2)
+
+	// Начальные коэффициенты Лагранжа из рядов
+	f1, g1 := 1-u*tau1*tau1/2, tau1-u*tau1*tau1*tau1/6
+	f3, g3 := 1-u*tau3*tau3/2, tau3-u*tau3*tau3*tau3/6
+
+	var best GaussSolution
+	var rho [3]float64
+	found := false
+	prevChange := math.Inf(1)
+	for range 50 {
+		det := f1*g3 - f3*g1
+		if det == 0 {
+			break
+		}
+		c1, c3 := g3/det, -g1/det
+
+		next := [3]float64{
+			(-d[0][0] + d[1][0]/c1 - d[2][0]*c3/c1) / d0,
+			(-c1*d[0][1] + d[1][1] - c3*d[2][1]) / d0,
+			(-c1/c3*d[0][2] + d[1][2]/c3 - d[2][2]) / d0,
+		}
+		if next[0] <= 0 || next[1] <= 0 || next[2] <= 0 || math.IsNaN(next[0]+next[1]+next[2]) {
+			break
+		}
+
+		change := math.Abs(next[0]-rho[0]) + math.Abs(next[1]-rho[1]) + math.Abs(next[2]-rho[2])
+		if found && change >= prevChange {
+			break
+		}
+		prevChange = change
+		rho = next
+
+		var r [3][3]float64
+		for i := range 3 {
+			r[i] = Add(sites[i], Scale(l[i], rho[i]))
+		}
+		v2 := Scale(Sub(Scale(r[2], f1), Scale(r[0], f3)), 1/det)
+
+		// Время излучения света, дошедшего до наблюдателя
+		t := [3]float64{
+			obs[0].JD - rho[0]/SpeedOfLight,
+			obs[1].JD - rho[1]/SpeedOfLight,
+			obs[2].JD - rho[2]/SpeedOfLight,
+		}
+		best = GaussSolution{JD: t[1], State: State{Position: r[1], Velocity: v2}}
+		found = true
+		if change < 1e-12 {
+			break
+		}
+
+		// Точные f и g по кеплеровскому движению
+		el, err := ElementsFromState(best.State, t[1])
+		if err != nil {
+			break
+		}
+		nf1, ng1, ok1 := lagrangeCoefficients(r[1], v2, el.PositionAt(t[0]))
+		nf3, ng3, ok3 := lagrangeCoefficients(r[1], v2, el.PositionAt(t[2]))
+		if !ok1 || !ok3 {
+			break
+		}
+		f1, g1, f3, g3 = nf1, ng1, nf3, ng3
+	}
+
+	if !found {
+		return GaussSolution{}, false
+	}
+	best.State = State{
+		Position: EquatorialToEcliptic(best.State.Position),
+		Velocity: EquatorialToEcliptic(best.State.Velocity),
+	}
+	return best, true
+}
+
+// lagrangeCoefficients находит f и g из разложения r = f r0 + g v0
+func lagrangeCoefficients(r0, v0, r [3]float64) (float64, float64, bool) {
+	rr, rv, vv := Dot(r0, r0), Dot(r0, v0), Dot(v0, v0)
+	det := rr*vv - rv*rv
+	if det == 0 {
+		return 0, 0, false
+	}
+	a, b := Dot(r0, r), Dot(v0, r)
+	return (a*vv - b*rv) / det, (b*rr - a*rv) / det, true
+}
+
+// positiveRoots находит корни функции на отрезке [lo, hi] по смене знака на логарифмической сетке
+func positiveRoots(f func(float64) float64, lo, hi float64) []float64 {
+	const steps = 2000
+	ratio := math.Pow(hi/lo, 1.0/steps)
+
+	var roots []float64
+	x0, f0 := lo, f(lo)
+	for range steps {
+		x1 := x0 * ratio
+		f1 := f(x1)
+		if f0 == 0 || f0*f1 < 0 {
+			roots = append(roots, bisect(f, x0, x1))
+		}
+		x0, f0 = x1, f1
+	}
+	sort.Float64s(roots)
+	return roots
+}
+
+func bisect(f func(float64) float64, lo, hi float64) float64 {
+	flo := f(lo)
+	for range 100 {
+		mid := (lo + hi) / 2
+		fm := f(mid)
+		if flo*fm <= 0 {
+			hi = mid
+		} else {
+			lo, flo = mid, fm
+		}
+	}
+	return (lo + hi) / 2
+}
@@ -0,0 +1,165 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNoHergetSolution метод Хергета не дал физически допустимого решения
+var ErrNoHergetSolution = errors.New("herget method found no admissible orbit")
+
+// Herget определяет орбиту методом Хергета: неизвестными считаются геоцентрические
+// расстояния в моменты первого и последнего наблюдений. По двум положениям задача
+// Ламберта дает орбиту (без полных оборотов между ними), а расстояния подбираются
+// по невязкам всех наблюдений. Короткий и длинный путь между положениями
+// перебираются оба. Эпоха решения — момент первого наблюдения.
+func Herget(obs []AngleObservation) (GaussSolution, error) {
+	sorted := append([]AngleObservation(nil), obs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].JD < sorted[j].JD })
+	if distinctEpochs(sorted) < 3 {
+		return GaussSolution{}, ErrNoHergetSolution
+	}
+
+	first, last := sorted[0], sorted[len(sorted)-1]
+	l1, l2 := UnitVector(first.RA, first.Dec), UnitVector(last.RA, last.Dec)
+	site1 := Add(EclipticToEquatorial(EarthPosition(first.JD)), first.Observer)
+	site2 := Add(EclipticToEquatorial(EarthPosition(last.JD)), last.Observer)
+
+	// x = (ln ρ1, ln ρ2)
+	transfer := func(x []float64, longWay bool) (GaussSolution, bool) {
+		rho1, rho2 := math.Exp(x[0]), math.Exp(x[1])
+		if math.IsInf(rho1, 0) || math.IsInf(rho2, 0) {
+			return GaussSolution{}, false
+		}
+		r1 := Add(site1, Scale(l1, rho1))
+		r2 := Add(site2, Scale(l2, rho2))
+		t1 := first.JD - rho1/SpeedOfLight
+		t2 := last.JD - rho2/SpeedOfLight
+
+		v1, ok := lambert(r1, r2, t2-t1, longWay)
+		if !ok {
+			return GaussSolution{}, false
+		}
+		return GaussSolution{
+			JD:    t1,
+			State: State{Position: EquatorialToEcliptic(r1), Velocity: EquatorialToEcliptic(v1)},
+		}, true
+	}
+
+	var best GaussSolution
+	bestCost := math.Inf(1)
+	for _, longWay := range []bool{false, true} {
+		residuals := func(x []float64) ([]float64, bool) {
+			s, ok := transfer(x, longWay)
+			if !ok {
+				return nil, false
+			}
+			return stateResiduals(s.State, s.JD, sorted)
+		}
+
+		var grid [][]float64
+		for rho1 := 0.03; rho1 < 30; rho1 *= 1.5 {
+			for rho2 := 0.03; rho2 < 30; rho2 *= 1.5 {
+				grid = append(grid, []float64{math.Log(rho1), math.Log(rho2)})
+			}
+		}
+
+		x, cost, ok := fitFromGrid(grid, residuals, 3, 100)
+		if !ok || cost >= bestCost {
+			continue
+		}
+		s, _ := transfer(x, longWay)
+		if Norm(Sub(s.State.Position, EarthPosition(s.JD))) < minGaussDistance {
+			continue
+		}
+		if _, err := ElementsFromState(s.State, s.JD); err != nil {
+			continue
+		}
+		best, bestCost = s, cost
+	}
+
+	if math.IsInf(bestCost, 1) {
+		return GaussSolution{}, ErrNoHergetSolution
+	}
+	return best, nil
+}
+
+// lambert решает задачу Ламберта в универсальных переменных без полных оборотов:
+// возвращает скорость в точке r1, при которой тело за время dt (сутки) приходит в r2
+func lambert(r1, r2 [3]float64, dt float64, longWay bool) ([3]float64, bool) {
+	n1, n2 := Norm(r1), Norm(r2)
+	if dt <= 0 || n1 == 0 || n2 == 0 {
+		return [3]float64{}, false
+	}
+
+	cosAngle := clamp(Dot(r1, r2)/(n1*n2), -1, 1)
+	sinAngle := math.Sqrt(1 - cosAngle*cosAngle)
+	if longWay {
+		sinAngle = -sinAngle
+	}
+	if 1-cosAngle < 1e-12 || sinAngle == 0 {
+		// Положения на одной прямой с Солнцем: плоскость орбиты не определена
+		return [3]float64{}, false
+	}
+	a := sinAngle * math.Sqrt(n1*n2/(1-cosAngle))
+
+	y := func(z float64) float64 {
+		return n1 + n2 + a*(z*stumpffS(z)-1)/math.Sqrt(stumpffC(z))
+	}
+	// Время перелета растет с z; при y ≤ 0 решения нет, такая точка лежит левее корня
+	flight := func(z float64) float64 {
+		yz := y(z)
+		if yz <= 0 {
+			return -1
+		}
+		return math.Pow(yz/stumpffC(z), 1.5)*stumpffS(z) + a*math.Sqrt(yz) - GaussK*dt
+	}
+
+	hi := 4*math.Pi*math.Pi - 1e-9
+	lo := -1.0
+	for flight(lo) > 0 {
+		lo *= 2
+		if lo < -1e4 {
+			return [3]float64{}, false
+		}
+	}
+	if math.IsNaN(flight(lo)) || flight(hi) < 0 {
+		return [3]float64{}, false
+	}
+	z := bisect(flight, lo, hi)
+
+	yz := y(z)
+	if yz <= 0 || math.IsNaN(yz) {
+		return [3]float64{}, false
+	}
+	f := 1 - yz/n1
+	g := a * math.Sqrt(yz/MuSun)
+	return Scale(Sub(r2, Scale(r1, f)), 1/g), true
+}
+
+// stumpffC функция Штумпфа C(z)
+func stumpffC(z float64) float64 {
+	switch {
+	case z > 1e-6:
+		return (1 - math.Cos(math.Sqrt(z))) / z
+	case z < -1e-6:
+		return (math.Cosh(math.Sqrt(-z)) - 1) / -z
+	default:
+		return 1.0/2 - z/24 + z*z/720
+	}
+}
+
+// stumpffS функция Штумпфа S(z)
+func stumpffS(z float64) float64 {
+	switch {
+	case z > 1e-6:
+		s := math.Sqrt(z)
+		return (s - math.Sin(s)) / (s * s * s)
+	case z < -1e-6:
+		s := math.Sqrt(-z)
+		return (math.Sinh(s) - s) / (s * s * s)
+	default:
+		return 1.0/6 - z/120 + z*z/5040
+	}
+}
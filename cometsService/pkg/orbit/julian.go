@@ -0,0 +1,27 @@
+package orbit
+
+import (
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/timescale"
+)
+
+// JDUnixEpoch юлианская дата начала эпохи Unix (1970-01-01T00:00:00Z)
+const JDUnixEpoch = timescale.JDUnixEpoch
+
+// JulianDate переводит момент времени в юлианскую дату TDB — шкалу, в которой
+// заданы эпохи элементов и эфемериды
+func JulianDate(t time.Time) float64 {
+	return timescale.JulianDate(t, timescale.TDB)
+}
+
+// TimeFromJulianDate переводит юлианскую дату TDB в момент времени UTC
+func TimeFromJulianDate(jd float64) time.Time {
+	return timescale.FromJulianDate(jd, timescale.TDB)
+}
+
+// JulianDateUT юлианская дата в шкале UTC (приближение UT1 с точностью до 0.9 с)
+// для расчетов, связанных с вращением Земли: звездного времени и положения пункта
+func JulianDateUT(t time.Time) float64 {
+	return timescale.JulianDate(t, timescale.UTC)
+}
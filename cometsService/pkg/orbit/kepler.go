@@ -0,0 +1,206 @@
+// Package orbit содержит расчеты невозмущенного кеплеровского движения
+// для эллиптических, параболических и гиперболических орбит.
+package orbit
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// GaussK гауссова гравитационная постоянная (а.е.^1.5 / сут)
+	GaussK = 0.01720209895
+	// MuSun гравитационный параметр Солнца (а.е.^3 / сут^2)
+	MuSun = GaussK * GaussK
+
+	deg = math.Pi / 180
+)
+
+var (
+	ErrParabolic       = errors.New("parabolic orbit has no finite semi-major axis")
+	ErrInvalidElements = errors.New("invalid orbital elements")
+)
+
+// Elements перигелийные элементы орбиты (эклиптика и равноденствие J2000).
+// Такая форма одинаково описывает эллипс, параболу и гиперболу.
+type Elements struct {
+	Q    float64 // Перигелийное расстояние, а.е.
+	E    float64 // Эксцентриситет
+	I    float64 // Наклонение, градусы
+	Node float64 // Долгота восходящего узла, градусы
+	Peri float64 // Аргумент перигелия, градусы
+	Tp   float64 // Момент прохождения перигелия, JD
+}
+
+// State гелиоцентрический вектор состояния (а.е., а.е./сут)
+type State struct {
+	Position [3]float64
+	Velocity [3]float64
+}
+
+// FromKeplerian строит перигелийные элементы по большой полуоси и истинной аномалии на эпоху.
+// Для гиперболы большая полуось отрицательна.
+func FromKeplerian(a, e, i, node, peri, nuDeg, epochJD float64) (Elements, error) {
+	if e < 0 || e == 1 || a == 0 || (e < 1) != (a > 0) {
+		return Elements{}, ErrInvalidElements
+	}
+
+	q := a * (1 - e)
+	n := GaussK / math.Pow(math.Abs(a), 1.5)
+	nu := nuDeg * deg
+
+	var m float64
+	if e < 1 {
+		ea := 2 * math.Atan(math.Sqrt((1-e)/(1+e))*math.Tan(nu/2))
+		m = ea - e*math.Sin(ea)
+	} else {
+		h := 2 * math.Atanh(math.Sqrt((e-1)/(e+1))*math.Tan(nu/2))
+		if math.IsNaN(h) || math.IsInf(h, 0) {
+			return Elements{}, ErrInvalidElements
+		}
+		m = e*math.Sinh(h) - h
+	}
+
+	return Elements{
+		Q:    q,
+		E:    e,
+		I:    i,
+		Node: node,
+		Peri: peri,
+		Tp:   epochJD - m/n,
+	}, nil
+}
+
+// SemiMajorAxis возвращает большую полуось (отрицательную для гиперболы)
+func (el Elements) SemiMajorAxis() (float64, error) {
+	if el.E == 1 {
+		return 0, ErrParabolic
+	}
+	return el.Q / (1 - el.E), nil
+}
+
+// Period возвращает период обращения в сутках; для незамкнутых орбит — +Inf
+func (el Elements) Period() float64 {
+	if el.E >= 1 {
+		return math.Inf(1)
+	}
+	a := el.Q / (1 - el.E)
+	return 2 * math.Pi / GaussK * math.Pow(a, 1.5)
+}
+
+// TrueAnomaly возвращает истинную аномалию (градусы, [0, 360)) и гелиоцентрическое расстояние на момент jd
+func (el Elements) TrueAnomaly(jd float64) (float64, float64) {
+	dt := jd - el.Tp
+	var nu, r float64
+
+	switch {
+	case el.E < 1:
+		a := el.Q / (1 - el.E)
+		n := GaussK / math.Pow(a, 1.5)
+		m := math.Remainder(n*dt, 2*math.Pi)
+		ea := solveElliptic(m, el.E)
+		nu = 2 * math.Atan2(math.Sqrt(1+el.E)*math.Sin(ea/2), math.Sqrt(1-el.E)*math.Cos(ea/2))
+		r = a * (1 - el.E*math.Cos(ea))
+	case el.E > 1:
+		a := el.Q / (el.E - 1)
+		n := GaussK / math.Pow(a, 1.5)
+		h := solveHyperbolic(n*dt, el.E)
+		nu = 2 * math.Atan(math.Sqrt((el.E+1)/(el.E-1))*math.Tanh(h/2))
+		r = a * (el.E*math.Cosh(h) - 1)
+	default:
+		// Уравнение Баркера: s^3/3 + s = W/2, s = tan(ν/2)
+		w := 3 * GaussK / math.Sqrt(2*el.Q*el.Q*el.Q) * dt
+		y := math.Cbrt(w/2 + math.Sqrt(w*w/4+1))
+		s := y - 1/y
+		nu = 2 * math.Atan(s)
+		r = el.Q * (1 + s*s)
+	}
+
+	nuDeg := math.Mod(nu/deg, 360)
+	if nuDeg < 0 {
+		nuDeg += 360
+	}
+	return nuDeg, r
+}
+
+// StateAt возвращает гелиоцентрические эклиптические положение и скорость на момент jd
+func (el Elements) StateAt(jd float64) State {
+	nuDeg, _ := el.TrueAnomaly(jd)
+	return el.StateAtAnomaly(nuDeg)
+}
+
+// StateAtAnomaly возвращает положение и скорость в точке орбиты с истинной аномалией nuDeg
+func (el Elements) StateAtAnomaly(nuDeg float64) State {
+	nu := nuDeg * deg
+	p := el.Q * (1 + el.E)
+	r := p / (1 + el.E*math.Cos(nu))
+
+	vr := GaussK / math.Sqrt(p) * el.E * math.Sin(nu)
+	vt := GaussK / math.Sqrt(p) * (1 + el.E*math.Cos(nu))
+
+	// Координаты в плоскости орбиты (ось x направлена в перигелий)
+	pos := [3]float64{r * math.Cos(nu), r * math.Sin(nu), 0}
+	vel := [3]float64{
+		vr*math.Cos(nu) - vt*math.Sin(nu),
+		vr*math.Sin(nu) + vt*math.Cos(nu),
+		0,
+	}
+
+	return State{
+		Position: el.toEcliptic(pos),
+		Velocity: el.toEcliptic(vel),
+	}
+}
+
+// PositionAt возвращает гелиоцентрическое эклиптическое положение на момент jd
+func (el Elements) PositionAt(jd float64) [3]float64 {
+	return el.StateAt(jd).Position
+}
+
+// toEcliptic поворачивает вектор из плоскости орбиты в эклиптическую систему
+func (el Elements) toEcliptic(v [3]float64) [3]float64 {
+	cw, sw := math.Cos(el.Peri*deg), math.Sin(el.Peri*deg)
+	cn, sn := math.Cos(el.Node*deg), math.Sin(el.Node*deg)
+	ci, si := math.Cos(el.I*deg), math.Sin(el.I*deg)
+
+	x := v[0]*cw - v[1]*sw
+	y := v[0]*sw + v[1]*cw
+
+	return [3]float64{
+		x*cn - y*ci*sn,
+		x*sn + y*ci*cn,
+		y * si,
+	}
+}
+
+// solveElliptic решает уравнение Кеплера E - e sin E = M методом Ньютона
+func solveElliptic(m, e float64) float64 {
+	ea := m
+	if e > 0.8 {
+		ea = math.Pi
+		if m < 0 {
+			ea = -math.Pi
+		}
+	}
+	for range 100 {
+		d := (ea - e*math.Sin(ea) - m) / (1 - e*math.Cos(ea))
+		ea -= d
+		if math.Abs(d) < 1e-14 {
+			break
+		}
+	}
+	return ea
+}
+
+// solveHyperbolic решает гиперболическое уравнение Кеплера e sinh H - H = M
+func solveHyperbolic(m, e float64) float64 {
+	h := math.Asinh(m / e)
+	for range 200 {
+		d := (e*math.Sinh(h) - h - m) / (e*math.Cosh(h) - 1)
+		h -= d
+		if math.Abs(d) < 1e-14 {
+			break
+		}
+	}
+	return h
+}
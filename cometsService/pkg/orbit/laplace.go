@@ -0,0 +1,129 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNoLaplaceSolution метод Лапласа не дал физически допустимого решения
+var ErrNoLaplaceSolution = errors.New("laplace method found no admissible orbit")
+
+// Laplace определяет предварительную орбиту методом Лапласа. Направление на тело
+// и его первая и вторая производные на середину дуги находятся квадратичной
+// аппроксимацией всех наблюдений, после чего геоцентрическое расстояние получается
+// совместно с уравнением движения. Смещение наблюдателя при аппроксимации не
+// учитывается, поэтому решения доуточняются по всем наблюдениям.
+// Решения возвращаются в том же виде, что и у метода Гаусса.
+func Laplace(obs []AngleObservation) ([]GaussSolution, error) {
+	sorted := append([]AngleObservation(nil), obs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].JD < sorted[j].JD })
+	if distinctEpochs(sorted) < 3 {
+		return nil, ErrNoLaplaceSolution
+	}
+
+	// L(τ) = L0 + L1·τ + L2·τ², τ отсчитывается от середины дуги
+	t0 := (sorted[0].JD + sorted[len(sorted)-1].JD) / 2
+	var normal [3][3]float64
+	var rhs [3][3]float64
+	for _, o := range sorted {
+		tau := o.JD - t0
+		basis := [3]float64{1, tau, tau * tau}
+		l := UnitVector(o.RA, o.Dec)
+		for i := range 3 {
+			for j := range 3 {
+				normal[i][j] += basis[i] * basis[j]
+			}
+			for c := range 3 {
+				rhs[c][i] += basis[i] * l[c]
+			}
+		}
+	}
+	var coef [3][3]float64 // coef[c] — коэффициенты компоненты c
+	for c := range 3 {
+		a := make([][]float64, 3)
+		for i := range 3 {
+			a[i] = append([]float64(nil), normal[i][:]...)
+		}
+		x, ok := solveN(a, append([]float64(nil), rhs[c][:]...))
+		if !ok {
+			return nil, ErrNoLaplaceSolution
+		}
+		copy(coef[c][:], x)
+	}
+
+	l := [3]float64{coef[0][0], coef[1][0], coef[2][0]}
+	l = Scale(l, 1/Norm(l))
+	dl := [3]float64{coef[0][1], coef[1][1], coef[2][1]}
+	ddl := [3]float64{2 * coef[0][2], 2 * coef[1][2], 2 * coef[2][2]}
+
+	site := EclipticToEquatorial(EarthPosition(t0))
+	siteVelocity := EclipticToEquatorial(EarthState(t0).Velocity)
+
+	d := Dot(l, Cross(dl, ddl))
+	if math.Abs(d) < 1e-18 {
+		// Видимое движение без кривизны: расстояние из него не определяется
+		return nil, ErrNoLaplaceSolution
+	}
+	n := Dot(l, Cross(dl, site))
+	m := Dot(l, Cross(site, ddl))
+	c := Dot(l, site)
+	r0 := Norm(site)
+
+	rho := func(r float64) float64 {
+		return MuSun * n / d * (1/(r0*r0*r0) - 1/(r*r*r))
+	}
+	// Геометрическое условие r² = ρ² + 2ρ(L·R) + R²
+	geometry := func(r float64) float64 {
+		p := rho(r)
+		return r*r - p*p - 2*p*c - r0*r0
+	}
+
+	var solutions []GaussSolution
+	for _, r := range positiveRoots(geometry, 0.01, 200) {
+		p := rho(r)
+		// Корень r = R соответствует ρ = 0 и существует всегда
+		if p < minGaussDistance {
+			continue
+		}
+		k := 1/(r0*r0*r0) - 1/(r*r*r)
+		pDot := MuSun * k * m / (2 * d)
+
+		position := Add(site, Scale(l, p))
+		velocity := Add(siteVelocity, Add(Scale(l, pDot), Scale(dl, p)))
+		s := GaussSolution{
+			JD: t0 - p/SpeedOfLight,
+			State: State{
+				Position: EquatorialToEcliptic(position),
+				Velocity: EquatorialToEcliptic(velocity),
+			},
+		}
+
+		if polished, _, _, ok := fitState(s.State, s.JD, sorted, 50); ok {
+			s.State = polished
+		}
+		if Norm(Sub(s.State.Position, EarthPosition(s.JD))) < minGaussDistance {
+			continue
+		}
+		if _, err := ElementsFromState(s.State, s.JD); err != nil {
+			continue
+		}
+		solutions = append(solutions, s)
+	}
+
+	if len(solutions) == 0 {
+		return nil, ErrNoLaplaceSolution
+	}
+	return solutions, nil
+}
+
+// distinctEpochs число различных моментов среди упорядоченных наблюдений
+func distinctEpochs(sorted []AngleObservation) int {
+	count := 0
+	for i, o := range sorted {
+		if i == 0 || o.JD != sorted[i-1].JD {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,89 @@
+package orbit
+
+import "math"
+
+// NodalEncounter встреча Земли с узлом орбиты, где возможна метеорная активность
+type NodalEncounter struct {
+	Ascending          bool    // true — восходящий узел, false — нисходящий
+	NodalDistance      float64 // Гелиоцентрическое расстояние узла, а.е.
+	MissDistance       float64 // Расстояние между узлом и Землей в момент встречи, а.е.
+	SolarLongitude     float64 // Солнечная долгота встречи (J2000), градусы
+	JD                 float64 // Ближайшая дата встречи начиная с fromJD
+	RadiantRA          float64 // Теоретический геоцентрический радиант (J2000), градусы
+	RadiantDec         float64
+	GeocentricVelocity float64 // Геоцентрическая скорость до притяжения Земли, км/с
+}
+
+// NodalEncounters возвращает встречи с узлами, лежащими не дальше maxMiss а.е. от орбиты Земли.
+// Радиант вычисляется без учета зенитного притяжения и суточной аберрации.
+func NodalEncounters(el Elements, fromJD, maxMiss float64) []NodalEncounter {
+	var encounters []NodalEncounter
+
+	for _, ascending := range []bool{true, false} {
+		nu := -el.Peri
+		nodeLongitude := el.Node
+		if !ascending {
+			nu = 180 - el.Peri
+			nodeLongitude = el.Node + 180
+		}
+
+		// На незамкнутой орбите узел может не существовать
+		if el.E >= 1 && 1+el.E*math.Cos(nu*deg) <= 0 {
+			continue
+		}
+
+		meteoroid := el.StateAtAnomaly(nu)
+		nodalDistance := Norm(meteoroid.Position)
+		if math.Abs(nodalDistance-1) > maxMiss {
+			continue
+		}
+
+		jd := earthLongitudeDate(normalizeDeg(nodeLongitude), fromJD)
+		earth := EarthState(jd)
+
+		miss := Norm(Sub(meteoroid.Position, earth.Position))
+		if miss > maxMiss {
+			continue
+		}
+
+		vg := Sub(meteoroid.Velocity, earth.Velocity)
+		radiant := EclipticToEquatorial([3]float64{-vg[0], -vg[1], -vg[2]})
+		ra, dec := RaDec(radiant)
+
+		encounters = append(encounters, NodalEncounter{
+			Ascending:          ascending,
+			NodalDistance:      nodalDistance,
+			MissDistance:       miss,
+			SolarLongitude:     normalizeDeg(nodeLongitude - 180),
+			JD:                 jd,
+			RadiantRA:          ra,
+			RadiantDec:         dec,
+			GeocentricVelocity: Norm(vg) * AUPerDayToKmPerSec,
+		})
+	}
+
+	return encounters
+}
+
+// EarthLongitude гелиоцентрическая эклиптическая долгота Земли, градусы
+func EarthLongitude(jd float64) float64 {
+	p := EarthPosition(jd)
+	return normalizeDeg(math.Atan2(p[1], p[0]) / deg)
+}
+
+// SolarLongitude геоцентрическая долгота Солнца, градусы
+func SolarLongitude(jd float64) float64 {
+	return normalizeDeg(EarthLongitude(jd) + 180)
+}
+
+// earthLongitudeDate ближайший после fromJD момент, когда Земля находится на долготе target
+func earthLongitudeDate(target, fromJD float64) float64 {
+	const meanMotion = 360 / 365.25636
+
+	diff := normalizeDeg(target - EarthLongitude(fromJD))
+	jd := fromJD + diff/meanMotion
+	for range 5 {
+		jd += math.Remainder(target-EarthLongitude(jd), 360) / meanMotion
+	}
+	return jd
+}
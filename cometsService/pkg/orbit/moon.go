@@ -0,0 +1,40 @@
+package orbit
+
+import "math"
+
+const (
+	// moonMassFraction доля массы Луны в системе Земля–Луна
+	moonMassFraction = 1 / (1 + 81.30057)
+	// earthRadiusAU экваториальный радиус Земли, а.е.
+	earthRadiusAU = 6378.137 / 149597870.7
+	// precessionRate общая прецессия по долготе, градусы на юлианское столетие
+	precessionRate = 1.3970
+)
+
+// MoonPosition геоцентрическое положение Луны (эклиптика и равноденствие J2000, а.е.)
+// по сокращенной теории Astronomical Almanac: около 0.3° по долготе и 0.2° по широте,
+// чего достаточно для перехода от барицентра Земля–Луна к центру Земли.
+func MoonPosition(jd float64) [3]float64 {
+	t := (jd - JDJ2000) / 36525
+	sin := func(a float64) float64 { return math.Sin(a * deg) }
+	cos := func(a float64) float64 { return math.Cos(a * deg) }
+
+	lon := 218.32 + 481267.881*t +
+		6.29*sin(135.0+477198.87*t) - 1.27*sin(259.3-413335.36*t) +
+		0.66*sin(235.7+890534.22*t) + 0.21*sin(269.9+954397.74*t) -
+		0.19*sin(357.5+35999.05*t) - 0.11*sin(186.5+966404.03*t)
+	lat := 5.13*sin(93.3+483202.02*t) + 0.28*sin(228.2+960400.89*t) -
+		0.28*sin(318.3+6003.15*t) - 0.17*sin(217.6-407332.21*t)
+	parallax := 0.9508 + 0.0518*cos(135.0+477198.87*t) + 0.0095*cos(259.3-413335.36*t) +
+		0.0078*cos(235.7+890534.22*t) + 0.0028*cos(269.9+954397.74*t)
+
+	// Долгота отсчитывается от равноденствия даты, приводим к J2000
+	lon -= precessionRate * t
+
+	r := earthRadiusAU / sin(parallax)
+	return [3]float64{
+		r * cos(lat) * cos(lon),
+		r * cos(lat) * sin(lon),
+		r * sin(lat),
+	}
+}
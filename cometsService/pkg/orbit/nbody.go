@@ -0,0 +1,127 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrIntegrationFailed = errors.New("numerical integration did not converge")
+
+const (
+	integrationTolerance = 1e-9 // Допустимая локальная ошибка шага, а.е.
+	minIntegrationStep   = 1e-6 // сутки
+	maxIntegrationSteps  = 1000000
+)
+
+// PerturbedAcceleration гелиоцентрическое ускорение тела с учетом притяжения
+// Солнца и прямых и косвенных возмущений от больших планет на момент jd
+func PerturbedAcceleration(jd float64, r [3]float64) [3]float64 {
+	rn := Norm(r)
+	acc := Scale(r, -MuSun/(rn*rn*rn))
+	for _, p := range Planets {
+		rp := p.State(jd).Position
+		d := Sub(rp, r)
+		dn, rpn := Norm(d), Norm(rp)
+		mu := MuSun * p.MassRatio
+		acc = Add(acc, Scale(d, mu/(dn*dn*dn)))
+		acc = Add(acc, Scale(rp, -mu/(rpn*rpn*rpn)))
+	}
+	return acc
+}
+
+// Propagate интегрирует движение тела с планетными возмущениями от jd0 к jd1
+// методом Дормана–Принса 5(4) с автоматическим выбором шага. Интегрирование
+// прекращается досрочно, если stop вернет true; возвращаются достигнутые состояние и момент.
+func Propagate(s State, jd0, jd1 float64, stop func(jd float64, s State) bool) (State, float64, error) {
+	dir := 1.0
+	if jd1 < jd0 {
+		dir = -1
+	}
+	y := stateVector(s)
+	jd := jd0
+	h := dir * math.Min(1, math.Abs(jd1-jd0))
+
+	for n := 0; n < maxIntegrationSteps; n++ {
+		if (jd1-jd)*dir <= 0 || (stop != nil && stop(jd, vectorState(y))) {
+			return vectorState(y), jd, nil
+		}
+		if (jd+h-jd1)*dir > 0 {
+			h = jd1 - jd
+		}
+
+		next, errEstimate := dormandPrinceStep(jd, y, h)
+		if errEstimate <= integrationTolerance || math.Abs(h) <= minIntegrationStep {
+			jd += h
+			y = next
+		}
+
+		// Новый шаг по оценке ошибки с запасом и ограничением роста
+		factor := 5.0
+		if errEstimate > 0 {
+			factor = math.Min(5, math.Max(0.2, 0.9*math.Pow(integrationTolerance/errEstimate, 0.2)))
+		}
+		h *= factor
+		if math.Abs(h) < minIntegrationStep {
+			h = dir * minIntegrationStep
+		}
+		if math.IsNaN(h) || math.IsNaN(y[0]) {
+			break
+		}
+	}
+	return State{}, jd, ErrIntegrationFailed
+}
+
+// Коэффициенты Дормана–Принса 5(4)
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpB5 = [7]float64{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84, 0}
+	dpB4 = [7]float64{5179.0 / 57600, 0, 7571.0 / 16695, 393.0 / 640, -92097.0 / 339200, 187.0 / 2100, 1.0 / 40}
+)
+
+// dormandPrinceStep делает шаг h и возвращает решение пятого порядка и оценку
+// ошибки положения как разность с решением четвертого порядка
+func dormandPrinceStep(jd float64, y [6]float64, h float64) ([6]float64, float64) {
+	var k [7][6]float64
+	for i := 0; i < 7; i++ {
+		yi := y
+		for j := 0; j < i; j++ {
+			for c := range yi {
+				yi[c] += h * dpA[i][j] * k[j][c]
+			}
+		}
+		k[i] = derivative(jd+dpC[i]*h, yi)
+	}
+
+	var y5, y4 [6]float64
+	for c := range y {
+		y5[c], y4[c] = y[c], y[c]
+		for i := 0; i < 7; i++ {
+			y5[c] += h * dpB5[i] * k[i][c]
+			y4[c] += h * dpB4[i] * k[i][c]
+		}
+	}
+	errEstimate := Norm([3]float64{y5[0] - y4[0], y5[1] - y4[1], y5[2] - y4[2]})
+	return y5, errEstimate
+}
+
+func derivative(jd float64, y [6]float64) [6]float64 {
+	acc := PerturbedAcceleration(jd, [3]float64{y[0], y[1], y[2]})
+	return [6]float64{y[3], y[4], y[5], acc[0], acc[1], acc[2]}
+}
+
+func stateVector(s State) [6]float64 {
+	return [6]float64{s.Position[0], s.Position[1], s.Position[2], s.Velocity[0], s.Velocity[1], s.Velocity[2]}
+}
+
+func vectorState(y [6]float64) State {
+	return State{Position: [3]float64{y[0], y[1], y[2]}, Velocity: [3]float64{y[3], y[4], y[5]}}
+}
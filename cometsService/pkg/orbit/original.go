@@ -0,0 +1,93 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// OriginalDistance гелиоцентрическое расстояние, на котором берутся
+	// исходная и будущая орбиты: там планетные возмущения пренебрежимо малы
+	OriginalDistance = 250.0
+
+	// Предельная продолжительность интегрирования в каждую сторону, сутки.
+	// Кометы с афелием за OriginalDistance достигают его намного быстрее.
+	maxOriginalSpan = 5000 * 365.25
+)
+
+var (
+	ErrNotLongPeriod      = errors.New("original and future orbits are defined only for comets reaching 250 AU")
+	ErrOriginalNotReached = errors.New("comet did not reach 250 AU within the integration span")
+)
+
+// ReachesOriginalDistance сообщает, уходит ли тело за OriginalDistance: для
+// эллиптической орбиты афелий q(1+e)/(1-e) должен быть дальше этого расстояния
+func (el Elements) ReachesOriginalDistance() bool {
+	if el.E >= 1 {
+		return true
+	}
+	return el.Q*(1+el.E)/(1-el.E) > OriginalDistance
+}
+
+// BarycentricOrbit барицентрическая оскулирующая орбита вдали от планет
+type BarycentricOrbit struct {
+	InverseA float64 // 1/a, 1/а.е.; отрицательное значение — гипербола
+	JD       float64 // Момент, на который получена орбита
+	Distance float64 // Гелиоцентрическое расстояние в этот момент, а.е.
+}
+
+// OriginalFuture исходная (до входа в планетную область) и будущая (после выхода) орбиты
+type OriginalFuture struct {
+	Original BarycentricOrbit
+	Future   BarycentricOrbit
+}
+
+// ComputeOriginalFuture интегрирует орбиту с планетными возмущениями назад и вперед
+// от эпохи jd до расстояния OriginalDistance и вычисляет барицентрическую 1/a.
+// Для орбит с афелием ближе OriginalDistance возвращает ErrNotLongPeriod без интегрирования.
+func ComputeOriginalFuture(el Elements, jd float64) (OriginalFuture, error) {
+	if !el.ReachesOriginalDistance() {
+		return OriginalFuture{}, ErrNotLongPeriod
+	}
+
+	start := el.StateAt(jd)
+	var result OriginalFuture
+	for _, dir := range []float64{-1, 1} {
+		// Останавливаемся только на удаляющейся от Солнца в направлении интегрирования ветви
+		stop := func(t float64, s State) bool {
+			return Norm(s.Position) >= OriginalDistance && dir*Dot(s.Position, s.Velocity) > 0
+		}
+		s, t, err := Propagate(start, jd, jd+dir*maxOriginalSpan, stop)
+		if err != nil {
+			return OriginalFuture{}, err
+		}
+		// Возмущения могли сократить афелий: орбита на конец интервала ничего не значит
+		if !stop(t, s) {
+			return OriginalFuture{}, ErrOriginalNotReached
+		}
+		inverseA := barycentricInverseA(s, t)
+		if !isFinite(inverseA) {
+			return OriginalFuture{}, ErrIntegrationFailed
+		}
+		orbit := BarycentricOrbit{InverseA: inverseA, JD: t, Distance: Norm(s.Position)}
+		if dir < 0 {
+			result.Original = orbit
+		} else {
+			result.Future = orbit
+		}
+	}
+	return result, nil
+}
+
+// barycentricInverseA обратная большая полуось относительно барицентра Солнечной системы
+func barycentricInverseA(s State, jd float64) float64 {
+	bary := Barycenter(jd)
+	r := Norm(Sub(s.Position, bary.Position))
+	v := Sub(s.Velocity, bary.Velocity)
+	return 2/r - Dot(v, v)/MuSystem()
+}
+
+// isFinite проверяет, что значение не NaN и не бесконечность
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
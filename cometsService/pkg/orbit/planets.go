@@ -0,0 +1,73 @@
+package orbit
+
+// Planet большая планета; Земля представлена барицентром Земля–Луна
+type Planet struct {
+	Name string
+	// MassRatio отношение массы планеты (с луной) к массе Солнца
+	MassRatio float64
+	elements  meanElements
+}
+
+// Planets восемь больших планет со средними элементами Standish (1800–2050)
+// и массами DE405. Вне этого интервала точность положений падает до долей градуса,
+// что допустимо для оценки планетных возмущений на протяжении нескольких веков.
+var Planets = []Planet{
+	{Name: "Mercury", MassRatio: 1 / 6023600.0, elements: meanElements{
+		A: 0.38709927, E: 0.20563593, I: 7.00497902, L: 252.25032350, LongPeri: 77.45779628, Node: 48.33076593,
+		ADot: 0.00000037, EDot: 0.00001906, IDot: -0.00594749, LDot: 149472.67411175, LongPeriDot: 0.16047689, NodeDot: -0.12534081,
+	}},
+	{Name: "Venus", MassRatio: 1 / 408523.71, elements: meanElements{
+		A: 0.72333566, E: 0.00677672, I: 3.39467605, L: 181.97909950, LongPeri: 131.60246718, Node: 76.67984255,
+		ADot: 0.00000390, EDot: -0.00004107, IDot: -0.00078890, LDot: 58517.81538729, LongPeriDot: 0.00268329, NodeDot: -0.27769418,
+	}},
+	{Name: "Earth", MassRatio: 1 / 328900.56, elements: earthMeanElements},
+	{Name: "Mars", MassRatio: 1 / 3098708.0, elements: meanElements{
+		A: 1.52371034, E: 0.09339410, I: 1.84969142, L: -4.55343205, LongPeri: -23.94362959, Node: 49.55953891,
+		ADot: 0.00001847, EDot: 0.00007882, IDot: -0.00813131, LDot: 19140.30268499, LongPeriDot: 0.44441088, NodeDot: -0.29257343,
+	}},
+	{Name: "Jupiter", MassRatio: 1 / 1047.3486, elements: meanElements{
+		A: 5.20288700, E: 0.04838624, I: 1.30439695, L: 34.39644051, LongPeri: 14.72847983, Node: 100.47390909,
+		ADot: -0.00011607, EDot: -0.00013253, IDot: -0.00183714, LDot: 3034.74612775, LongPeriDot: 0.21252668, NodeDot: 0.20469106,
+	}},
+	{Name: "Saturn", MassRatio: 1 / 3497.898, elements: meanElements{
+		A: 9.53667594, E: 0.05386179, I: 2.48599187, L: 49.95424423, LongPeri: 92.59887831, Node: 113.66242448,
+		ADot: -0.00125060, EDot: -0.00050991, IDot: 0.00193609, LDot: 1222.49362201, LongPeriDot: -0.41897216, NodeDot: -0.28867794,
+	}},
+	{Name: "Uranus", MassRatio: 1 / 22902.98, elements: meanElements{
+		A: 19.18916464, E: 0.04725744, I: 0.77263783, L: 313.23810451, LongPeri: 170.95427630, Node: 74.01692503,
+		ADot: -0.00196176, EDot: -0.00004397, IDot: -0.00242939, LDot: 428.48202785, LongPeriDot: 0.40805281, NodeDot: 0.04240589,
+	}},
+	{Name: "Neptune", MassRatio: 1 / 19412.24, elements: meanElements{
+		A: 30.06992276, E: 0.00859048, I: 1.77004347, L: -55.12002969, LongPeri: 44.96476227, Node: 131.78422574,
+		ADot: 0.00026291, EDot: 0.00005105, IDot: 0.00035372, LDot: 218.45945325, LongPeriDot: -0.32241464, NodeDot: -0.00508664,
+	}},
+}
+
+// State гелиоцентрический вектор состояния планеты на момент jd
+func (p Planet) State(jd float64) State {
+	return planetElements(jd, p.elements).StateAt(jd)
+}
+
+// Barycenter гелиоцентрический вектор состояния барицентра Солнечной системы на момент jd
+func Barycenter(jd float64) State {
+	var bary State
+	total := 1.0
+	for _, p := range Planets {
+		s := p.State(jd)
+		bary.Position = Add(bary.Position, Scale(s.Position, p.MassRatio))
+		bary.Velocity = Add(bary.Velocity, Scale(s.Velocity, p.MassRatio))
+		total += p.MassRatio
+	}
+	bary.Position = Scale(bary.Position, 1/total)
+	bary.Velocity = Scale(bary.Velocity, 1/total)
+	return bary
+}
+
+// MuSystem гравитационный параметр Солнца вместе с планетами (а.е.^3 / сут^2)
+func MuSystem() float64 {
+	total := 1.0
+	for _, p := range Planets {
+		total += p.MassRatio
+	}
+	return MuSun * total
+}
@@ -0,0 +1,126 @@
+package orbit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Criterion критерий сходства орбит
+type Criterion string
+
+const (
+	// CriterionSH критерий Southworth–Hawkins (1963)
+	CriterionSH Criterion = "sh"
+	// CriterionDrummond критерий Drummond D' (1981)
+	CriterionDrummond Criterion = "d"
+	// CriterionDH критерий Jopek D_H (1993)
+	CriterionDH Criterion = "dh"
+)
+
+// DefaultThreshold пороги, общепринятые для выделения родственных орбит
+var DefaultThreshold = map[Criterion]float64{
+	CriterionSH:       0.2,
+	CriterionDrummond: 0.105,
+	CriterionDH:       0.18,
+}
+
+// ParseCriterion разбирает название критерия; пустая строка означает D_SH
+func ParseCriterion(s string) (Criterion, error) {
+	switch c := Criterion(strings.ToLower(strings.TrimSpace(s))); c {
+	case "":
+		return CriterionSH, nil
+	case CriterionSH, CriterionDrummond, CriterionDH:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown similarity criterion %q (expected sh, d or dh)", s)
+	}
+}
+
+// Distance вычисляет расстояние между орбитами по выбранному критерию
+func Distance(c Criterion, a, b Elements) float64 {
+	switch c {
+	case CriterionDrummond:
+		return DDrummond(a, b)
+	case CriterionDH:
+		return DH(a, b)
+	default:
+		return DSH(a, b)
+	}
+}
+
+// DSH критерий Southworth–Hawkins
+func DSH(a, b Elements) float64 {
+	dq := b.Q - a.Q
+	return math.Sqrt(dshCommon(a, b) + dq*dq)
+}
+
+// DH критерий Jopek: вариант D_SH с нормированной разностью перигелийных расстояний
+func DH(a, b Elements) float64 {
+	dq := (b.Q - a.Q) / (b.Q + a.Q)
+	return math.Sqrt(dshCommon(a, b) + dq*dq)
+}
+
+// dshCommon слагаемые D_SH, не зависящие от перигелийного расстояния
+func dshCommon(a, b Elements) float64 {
+	de := b.E - a.E
+	i21 := mutualInclination(a, b)
+	pi21 := perihelionLongitudeDiff(a, b, i21)
+
+	se := (a.E + b.E) / 2
+	twoSinI := 2 * math.Sin(i21/2)
+	twoSinPi := 2 * math.Sin(pi21/2)
+
+	return de*de + twoSinI*twoSinI + se*se*twoSinPi*twoSinPi
+}
+
+// DDrummond критерий Drummond D'
+func DDrummond(a, b Elements) float64 {
+	de := (b.E - a.E) / (b.E + a.E)
+	dq := (b.Q - a.Q) / (b.Q + a.Q)
+	i21 := mutualInclination(a, b)
+
+	lambda1, beta1 := perihelionDirection(a)
+	lambda2, beta2 := perihelionDirection(b)
+	cosTheta := math.Cos(beta1)*math.Cos(beta2)*math.Cos(lambda2-lambda1) + math.Sin(beta1)*math.Sin(beta2)
+	theta21 := math.Acos(clamp(cosTheta, -1, 1))
+
+	se := (a.E + b.E) / 2
+	return math.Sqrt(de*de + dq*dq + (i21/math.Pi)*(i21/math.Pi) + se*se*(theta21/math.Pi)*(theta21/math.Pi))
+}
+
+// mutualInclination угол между плоскостями орбит, радианы
+func mutualInclination(a, b Elements) float64 {
+	i1, i2 := a.I*deg, b.I*deg
+	dNode := (b.Node - a.Node) * deg
+
+	s1 := 2 * math.Sin((i2-i1)/2)
+	s2 := 2 * math.Sin(dNode/2)
+	twoSin := math.Sqrt(s1*s1 + math.Sin(i1)*math.Sin(i2)*s2*s2)
+	return 2 * math.Asin(clamp(twoSin/2, -1, 1))
+}
+
+// perihelionLongitudeDiff разность долгот перигелиев, отсчитанных от точки пересечения орбит
+func perihelionLongitudeDiff(a, b Elements, i21 float64) float64 {
+	dNode := math.Remainder((b.Node-a.Node)*deg, 2*math.Pi)
+	cosHalf := math.Cos(i21 / 2)
+
+	var correction float64
+	if cosHalf > 1e-12 {
+		correction = 2 * math.Asin(clamp(math.Cos((a.I+b.I)*deg/2)*math.Sin(dNode/2)/cosHalf, -1, 1))
+	}
+	// remainder уже приводит разность узлов к (-π, π], что задает знак поправки
+	return (b.Peri-a.Peri)*deg + correction
+}
+
+// perihelionDirection эклиптические долгота и широта перигелия, радианы
+func perihelionDirection(el Elements) (float64, float64) {
+	i, w := el.I*deg, el.Peri*deg
+	lambda := el.Node*deg + math.Atan2(math.Cos(i)*math.Sin(w), math.Cos(w))
+	beta := math.Asin(math.Sin(i) * math.Sin(w))
+	return lambda, beta
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,60 @@
+package orbit
+
+import "math"
+
+const (
+	// EarthRadiusKm экваториальный радиус Земли (WGS84), км
+	EarthRadiusKm = 6378.137
+	// AUKm астрономическая единица, км
+	AUKm = 149597870.7
+
+	earthFlattening = 1 / 298.257223563
+)
+
+// Site пункт наблюдения
+type Site struct {
+	Latitude  float64 // Геодезическая широта, градусы
+	Longitude float64 // Долгота, градусы (к востоку положительная)
+	Altitude  float64 // Высота над эллипсоидом, м
+}
+
+// GMST гринвичское среднее звездное время (IAU 1982) на юлианскую дату UT, градусы
+func GMST(jd float64) float64 {
+	t := (jd - JDJ2000) / 36525
+	gmst := 280.46061837 + 360.98564736629*(jd-JDJ2000) + 0.000387933*t*t - t*t*t/38710000
+	return normalizeDeg(gmst)
+}
+
+// LocalSiderealTime местное среднее звездное время пункта, градусы
+func (s Site) LocalSiderealTime(jd float64) float64 {
+	return normalizeDeg(GMST(jd) + s.Longitude)
+}
+
+// GeocentricPosition геоцентрический экваториальный вектор пункта на момент jd (UT), а.е.
+// Прецессия и нутация не учитываются: для параллакса их вклад пренебрежимо мал.
+func (s Site) GeocentricPosition(jd float64) [3]float64 {
+	phi := s.Latitude * deg
+	b := 1 - earthFlattening
+	c := 1 / math.Sqrt(math.Cos(phi)*math.Cos(phi)+b*b*math.Sin(phi)*math.Sin(phi))
+	h := s.Altitude / 1000
+
+	rhoCos := (EarthRadiusKm*c + h) * math.Cos(phi) / AUKm
+	rhoSin := (EarthRadiusKm*b*b*c + h) * math.Sin(phi) / AUKm
+	lst := s.LocalSiderealTime(jd) * deg
+
+	return [3]float64{rhoCos * math.Cos(lst), rhoCos * math.Sin(lst), rhoSin}
+}
+
+// ObjectAltitude высота светила с координатами ra, dec над горизонтом пункта на момент jd (UT), градусы
+func (s Site) ObjectAltitude(raDeg, decDeg, jd float64) float64 {
+	hourAngle := (s.LocalSiderealTime(jd) - raDeg) * deg
+	phi, dec := s.Latitude*deg, decDeg*deg
+	sinAlt := math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(hourAngle)
+	return math.Asin(clamp(sinAlt, -1, 1)) / deg
+}
+
+// SunRaDec геоцентрические координаты Солнца (J2000), градусы
+func SunRaDec(jd float64) (float64, float64) {
+	earth := EarthPosition(jd)
+	return RaDec(EclipticToEquatorial([3]float64{-earth[0], -earth[1], -earth[2]}))
+}
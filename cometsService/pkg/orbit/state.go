@@ -0,0 +1,78 @@
+package orbit
+
+import "math"
+
+// Cross векторное произведение
+func Cross(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// Scale умножение вектора на число
+func Scale(v [3]float64, k float64) [3]float64 {
+	return [3]float64{v[0] * k, v[1] * k, v[2] * k}
+}
+
+// ElementsFromState восстанавливает перигелийные элементы по вектору состояния на момент jd.
+// Элементы получаются в той же системе координат, что и вектор состояния.
+func ElementsFromState(s State, jd float64) (Elements, error) {
+	r, v := s.Position, s.Velocity
+	rn := Norm(r)
+	h := Cross(r, v)
+	hn := Norm(h)
+	if rn == 0 || hn == 0 {
+		return Elements{}, ErrInvalidElements
+	}
+
+	// Вектор эксцентриситета направлен в перигелий
+	ev := Scale(Sub(Scale(r, Dot(v, v)-MuSun/rn), Scale(v, Dot(r, v))), 1/MuSun)
+	e := Norm(ev)
+
+	inc := math.Acos(clamp(h[2]/hn, -1, 1))
+
+	// Для орбит в плоскости эклиптики узел не определен, берем направление оси x
+	node := [3]float64{-h[1], h[0], 0}
+	if Norm(node) < 1e-12*hn {
+		node = [3]float64{1, 0, 0}
+	}
+	nodeLon := math.Atan2(node[1], node[0])
+
+	// Для почти круговых орбит перигелий отсчитывается от узла
+	periDir := ev
+	if e < 1e-12 {
+		periDir = node
+	}
+	hu := Scale(h, 1/hn)
+	peri := math.Atan2(Dot(Cross(node, periDir), hu), Dot(node, periDir))
+	nu := math.Atan2(Dot(Cross(periDir, r), hu), Dot(periDir, r))
+
+	p := hn * hn / MuSun
+	q := p / (1 + e)
+
+	var dt float64
+	switch {
+	case math.Abs(e-1) < 1e-10:
+		s := math.Tan(nu / 2)
+		dt = (s*s*s + 3*s) * math.Sqrt(2*q*q*q) / (3 * GaussK)
+		e = 1
+	case e < 1:
+		a := q / (1 - e)
+		ea := 2 * math.Atan(math.Sqrt((1-e)/(1+e))*math.Tan(nu/2))
+		dt = (ea - e*math.Sin(ea)) / (GaussK / math.Pow(a, 1.5))
+	default:
+		a := q / (e - 1)
+		hh := 2 * math.Atanh(math.Sqrt((e-1)/(e+1))*math.Tan(nu/2))
+		if math.IsNaN(hh) || math.IsInf(hh, 0) {
+			return Elements{}, ErrInvalidElements
+		}
+		dt = (e*math.Sinh(hh) - hh) / (GaussK / math.Pow(a, 1.5))
+	}
+
+	return Elements{
+		Q:    q,
+		E:    e,
+		I:    inc / deg,
+		Node: normalizeDeg(nodeLon / deg),
+		Peri: normalizeDeg(peri / deg),
+		Tp:   jd - dt,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package orbit
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNoVaisalaSolution метод Вяйсяля не дал физически допустимого решения
+var ErrNoVaisalaSolution = errors.New("vaisala method found no admissible orbit")
+
+// maxVaisalaEccentricity верхняя граница эксцентриситета: по короткой дуге он
+// определяется плохо и без ограничения уходит к вырожденным гиперболам
+const maxVaisalaEccentricity = 1.5
+
+// Vaisala определяет орбиту по короткой дуге методом Вяйсяля: тело считается
+// находящимся в перигелии в момент наблюдения, ближайшего к середине дуги. Тогда
+// скорость перпендикулярна радиус-вектору и орбита задается тремя параметрами —
+// геоцентрическим расстоянием, эксцентриситетом и направлением скорости, — которые
+// подбираются по всем наблюдениям. Достаточно двух наблюдений в разные моменты.
+func Vaisala(obs []AngleObservation) (GaussSolution, error) {
+	sorted := append([]AngleObservation(nil), obs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].JD < sorted[j].JD })
+	if distinctEpochs(sorted) < 2 {
+		return GaussSolution{}, ErrNoVaisalaSolution
+	}
+
+	mid := (sorted[0].JD + sorted[len(sorted)-1].JD) / 2
+	ref := sorted[0]
+	for _, o := range sorted {
+		if math.Abs(o.JD-mid) < math.Abs(ref.JD-mid) {
+			ref = o
+		}
+	}
+	l := UnitVector(ref.RA, ref.Dec)
+	site := Add(EclipticToEquatorial(EarthPosition(ref.JD)), ref.Observer)
+
+	// x = (ln ρ, asin √(e/emax), ψ)
+	perihelion := func(x []float64) (GaussSolution, bool) {
+		rho := math.Exp(x[0])
+		e := maxVaisalaEccentricity * math.Pow(math.Sin(x[1]), 2)
+		r := Add(site, Scale(l, rho))
+		q := Norm(r)
+		if q == 0 || math.IsNaN(q) || math.IsInf(rho, 0) {
+			return GaussSolution{}, false
+		}
+
+		// Базис плоскости, перпендикулярной радиус-вектору
+		u := Scale(r, 1/q)
+		axis := [3]float64{0, 0, 1}
+		if math.Abs(u[2]) > 0.9 {
+			axis = [3]float64{1, 0, 0}
+		}
+		b1 := Cross(axis, u)
+		b1 = Scale(b1, 1/Norm(b1))
+		b2 := Cross(u, b1)
+
+		speed := math.Sqrt(MuSun * (1 + e) / q)
+		v := Scale(Add(Scale(b1, math.Cos(x[2])), Scale(b2, math.Sin(x[2]))), speed)
+		return GaussSolution{
+			JD:    ref.JD - rho/SpeedOfLight,
+			State: State{Position: EquatorialToEcliptic(r), Velocity: EquatorialToEcliptic(v)},
+		}, true
+	}
+	residuals := func(x []float64) ([]float64, bool) {
+		s, ok := perihelion(x)
+		if !ok {
+			return nil, false
+		}
+		return stateResiduals(s.State, s.JD, sorted)
+	}
+
+	var grid [][]float64
+	for rho := 0.02; rho < 50; rho *= 1.4 {
+		for _, e := range []float64{0.1, 0.5, 0.9, 0.99} {
+			for psi := 0; psi < 360; psi += 15 {
+				grid = append(grid, []float64{math.Log(rho), math.Asin(math.Sqrt(e / maxVaisalaEccentricity)), float64(psi) * deg})
+			}
+		}
+	}
+
+	x, _, ok := fitFromGrid(grid, residuals, 3, 100)
+	if !ok {
+		return GaussSolution{}, ErrNoVaisalaSolution
+	}
+	s, ok := perihelion(x)
+	if !ok || Norm(Sub(s.State.Position, EarthPosition(s.JD))) < minGaussDistance {
+		return GaussSolution{}, ErrNoVaisalaSolution
+	}
+	if _, err := ElementsFromState(s.State, s.JD); err != nil {
+		return GaussSolution{}, ErrNoVaisalaSolution
+	}
+	return s, nil
+}
@@ -0,0 +1,94 @@
+package photometry
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	auCm = 1.495978707e13 // Астрономическая единица, см
+
+	arcsecRad = math.Pi / (180 * 3600)
+
+	// DefaultPhaseCoefficient линейный фазовый коэффициент пыли, зв. вел./градус;
+	// приближение составной фазовой функции Шлейхера при углах до ~60°
+	DefaultPhaseCoefficient = 0.035
+)
+
+var ErrUnknownFilter = errors.New("unknown photometric filter")
+
+// Видимые звездные величины Солнца на расстоянии 1 а.е. (Willmer 2018):
+// Джонсон–Казинс в системе Веги, SDSS в системе AB
+var solarMagnitudes = map[string]float64{
+	"B":  -26.13,
+	"V":  -26.76,
+	"Rc": -27.15,
+	"Ic": -27.47,
+	"g'": -26.52,
+	"r'": -26.96,
+	"i'": -27.05,
+}
+
+// Синонимы обозначений фильтров
+var filterAliases = map[string]string{
+	"B": "B", "V": "V",
+	"R": "Rc", "RC": "Rc",
+	"I": "Ic", "IC": "Ic",
+	"g": "g'", "g'": "g'", "sdss_g": "g'",
+	"r": "r'", "r'": "r'", "sdss_r": "r'",
+	"i": "i'", "i'": "i'", "sdss_i": "i'",
+}
+
+// NormalizeFilter приводит обозначение фильтра к каноническому: B, V, Rc, Ic, g', r', i'.
+// Одиночные заглавные R и I — фильтры Казинса, строчные g, r, i — SDSS.
+func NormalizeFilter(filter string) (string, error) {
+	f := strings.TrimSpace(filter)
+	if name, ok := filterAliases[f]; ok {
+		return name, nil
+	}
+	if name, ok := filterAliases[strings.ToUpper(f)]; ok {
+		return name, nil
+	}
+	return "", ErrUnknownFilter
+}
+
+// SolarMagnitude видимая звездная величина Солнца в фильтре на расстоянии 1 а.е.
+func SolarMagnitude(filter string) (float64, error) {
+	name, err := NormalizeFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+	return solarMagnitudes[name], nil
+}
+
+// AfRhoPoint измерение блеска в круглой диафрагме
+type AfRhoPoint struct {
+	Magnitude      float64 // Блеск комы в диафрагме
+	SolarMagnitude float64 // Блеск Солнца в том же фильтре
+	ApertureArcsec float64 // Радиус диафрагмы, угловые секунды
+	R              float64 // Гелиоцентрическое расстояние, а.е.
+	Delta          float64 // Геоцентрическое расстояние, а.е.
+	Phase          float64 // Фазовый угол, градусы
+}
+
+// AfRho результат расчета пылепроизводительности
+type AfRho struct {
+	RhoKm  float64 // Радиус диафрагмы в проекции на кому, км
+	AfRho  float64 // A(θ)fρ на фазовом угле наблюдения, см
+	AfRho0 float64 // A(0°)fρ, приведенный к нулевому фазовому углу, см
+}
+
+// ComputeAfRho вычисляет Afρ по A'Hearn et al. (1984):
+// Afρ = (2 r Δ)² / ρ · 10^(0.4 (m☉ − m)), где r в а.е., Δ и ρ в см.
+// Приведение к нулевой фазе — линейным законом с коэффициентом beta, зв. вел./градус.
+func ComputeAfRho(p AfRhoPoint, beta float64) AfRho {
+	deltaCm := p.Delta * auCm
+	rho := deltaCm * math.Tan(p.ApertureArcsec*arcsecRad)
+	afrho := math.Pow(2*p.R*deltaCm, 2) / rho * math.Pow(10, 0.4*(p.SolarMagnitude-p.Magnitude))
+	return AfRho{
+		RhoKm:  rho / 1e5,
+		AfRho:  afrho,
+		AfRho0: afrho * math.Pow(10, 0.4*beta*p.Phase),
+	}
+}
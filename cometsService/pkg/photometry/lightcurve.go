@@ -0,0 +1,120 @@
+// Package photometry анализирует блеск комет: закон изменения полной звездной
+// величины с расстоянием и пылепроизводительность.
+package photometry
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// DefaultK1 коэффициент активности, принимаемый при слишком узком диапазоне
+	// гелиоцентрических расстояний (закон m = M1 + 5 lg Δ + 10 lg r)
+	DefaultK1 = 4.0
+
+	// Минимальный разброс lg r, при котором K1 определяется из наблюдений
+	minLogRSpread = 0.02
+)
+
+var ErrTooFewPoints = errors.New("not enough magnitude estimates for light curve fit")
+
+// MagnitudePoint оценка полного блеска с расстояниями на момент наблюдения
+type MagnitudePoint struct {
+	Magnitude float64
+	R         float64 // Гелиоцентрическое расстояние, а.е.
+	Delta     float64 // Геоцентрическое расстояние, а.е.
+}
+
+// MagnitudeLaw параметры закона m = M1 + 5 lg Δ + 2.5 K1 lg r
+type MagnitudeLaw struct {
+	M1      float64
+	K1      float64
+	SigmaM1 float64 // Стандартные ошибки; 0, если точек не хватает для их оценки
+	SigmaK1 float64
+	RMS     float64 // Среднеквадратичное отклонение наблюдений от закона, зв. вел.
+	Points  int
+	FixedK1 bool // K1 не определялся, а принят равным DefaultK1
+}
+
+// Predict звездная величина по закону на расстояниях r и delta
+func (l MagnitudeLaw) Predict(r, delta float64) float64 {
+	return l.M1 + 5*math.Log10(delta) + 2.5*l.K1*math.Log10(r)
+}
+
+// Residual отклонение наблюдения от закона (O−C): положительное — комета слабее расчетной
+func (l MagnitudeLaw) Residual(p MagnitudePoint) float64 {
+	return p.Magnitude - l.Predict(p.R, p.Delta)
+}
+
+// FitMagnitudeLaw определяет M1 и K1 методом наименьших квадратов.
+// При одной точке или малом разбросе расстояний K1 фиксируется равным DefaultK1.
+func FitMagnitudeLaw(points []MagnitudePoint) (MagnitudeLaw, error) {
+	var valid []MagnitudePoint
+	for _, p := range points {
+		if p.R > 0 && p.Delta > 0 && !math.IsNaN(p.Magnitude) {
+			valid = append(valid, p)
+		}
+	}
+	n := len(valid)
+	if n == 0 {
+		return MagnitudeLaw{}, ErrTooFewPoints
+	}
+
+	// y = M1 + K1·x, где y = m − 5 lg Δ, x = 2.5 lg r
+	xs, ys := make([]float64, n), make([]float64, n)
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for i, p := range valid {
+		xs[i] = 2.5 * math.Log10(p.R)
+		ys[i] = p.Magnitude - 5*math.Log10(p.Delta)
+		minX, maxX = math.Min(minX, xs[i]), math.Max(maxX, xs[i])
+	}
+
+	law := MagnitudeLaw{Points: n}
+	if n < 3 || (maxX-minX)/2.5 < minLogRSpread {
+		law.K1, law.FixedK1 = DefaultK1, true
+		sum := 0.0
+		for i := range ys {
+			sum += ys[i] - DefaultK1*xs[i]
+		}
+		law.M1 = sum / float64(n)
+		law.RMS = rms(valid, law)
+		if n > 1 {
+			law.SigmaM1 = law.RMS / math.Sqrt(float64(n-1))
+		}
+		return law, nil
+	}
+
+	meanX, meanY := mean(xs), mean(ys)
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+	law.K1 = sxy / sxx
+	law.M1 = meanY - law.K1*meanX
+	law.RMS = rms(valid, law)
+
+	// Несмещенная оценка дисперсии остатков с n−2 степенями свободы
+	variance := law.RMS * law.RMS * float64(n) / float64(n-2)
+	law.SigmaK1 = math.Sqrt(variance / sxx)
+	law.SigmaM1 = math.Sqrt(variance * (1/float64(n) + meanX*meanX/sxx))
+	return law, nil
+}
+
+func rms(points []MagnitudePoint, law MagnitudeLaw) float64 {
+	sum := 0.0
+	for _, p := range points {
+		d := law.Residual(p)
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(points)))
+}
+
+func mean(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
@@ -0,0 +1,56 @@
+package photometry
+
+import "sort"
+
+// DetectOutbursts ищет оценки блеска, которые ярче закона более чем на threshold
+// звездных величин. Закон подбирается только по «спокойным» точкам: самая яркая
+// подозрительная оценка исключается, закон пересчитывается, и так до тех пор,
+// пока отклонений не останется. Возвращает итоговый закон и индексы вспышек.
+func DetectOutbursts(points []MagnitudePoint, threshold float64) (MagnitudeLaw, []int, error) {
+	excluded := make(map[int]bool)
+	quiet := func() []MagnitudePoint {
+		var result []MagnitudePoint
+		for i, p := range points {
+			if !excluded[i] {
+				result = append(result, p)
+			}
+		}
+		return result
+	}
+
+	law, err := FitMagnitudeLaw(points)
+	if err != nil {
+		return MagnitudeLaw{}, nil, err
+	}
+
+	// Хотя бы две точки должны остаться для определения закона
+	for len(points)-len(excluded) > 2 {
+		worst, worstResidual := -1, -threshold
+		for i, p := range points {
+			if excluded[i] {
+				continue
+			}
+			if r := law.Residual(p); r < worstResidual {
+				worst, worstResidual = i, r
+			}
+		}
+		if worst < 0 {
+			break
+		}
+
+		excluded[worst] = true
+		if law, err = FitMagnitudeLaw(quiet()); err != nil {
+			return MagnitudeLaw{}, nil, err
+		}
+	}
+
+	// Исключенные точки, которые по итоговому закону не выходят за порог, вспышками не считаются
+	var outbursts []int
+	for i := range excluded {
+		if law.Residual(points[i]) < -threshold {
+			outbursts = append(outbursts, i)
+		}
+	}
+	sort.Ints(outbursts)
+	return law, outbursts, nil
+}
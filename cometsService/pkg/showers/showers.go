@@ -0,0 +1,168 @@
+// Package showers загружает каталог орбит метеорных потоков в формате рабочего списка IAU MDC.
+package showers
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Встроенная подборка установленных потоков используется, если файл не указан
+//
+//go:embed established.csv
+var established string
+
+// Shower метеорный поток со средней орбитой (эклиптика J2000)
+type Shower struct {
+	IAUNumber            int
+	Code                 string
+	Name                 string
+	SolarLongitude       float64 // Солнечная долгота максимума, градусы
+	RadiantRA            float64 // Радиант, градусы
+	RadiantDec           float64
+	GeocentricVelocity   float64 // км/с
+	PerihelionDistance   float64 // а.е.
+	Eccentricity         float64
+	InclinationDeg       float64
+	RaanDeg              float64
+	ArgumentOfPerihelion float64
+	ParentBody           string // Родительское тело по данным MDC
+}
+
+// defaultColumns порядок колонок streamfulldata.csv IAU MDC, если в файле нет заголовка
+var defaultColumns = []string{
+	"lp", "iauno", "adno", "code", "shower name", "activity", "s", "lasun", "ra", "de",
+	"dra", "dde", "vg", "a", "q", "e", "peri", "node", "inc", "n", "group", "cg", "parent body",
+}
+
+// Default возвращает встроенный каталог потоков
+func Default() ([]*Shower, error) {
+	return Parse(strings.NewReader(established))
+}
+
+// LoadFile загружает каталог потоков из локального файла формата IAU MDC
+func LoadFile(path string) ([]*Shower, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse разбирает файл IAU MDC: поля разделены "|", строки-комментарии начинаются с ":" или "#".
+// Строка заголовка необязательна; без нее используется порядок колонок streamfulldata.csv.
+func Parse(r io.Reader) ([]*Shower, error) {
+	var (
+		showers []*Shower
+		index   map[string]int
+	)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ":") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "|")
+		for i := range fields {
+			fields[i] = strings.Trim(strings.TrimSpace(fields[i]), `"`)
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		if index == nil {
+			if isHeader(fields) {
+				index = columnIndex(fields)
+				continue
+			}
+			index = columnIndex(defaultColumns)
+		}
+
+		shower, err := parseShower(fields, index)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if shower != nil {
+			showers = append(showers, shower)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return showers, nil
+}
+
+func isHeader(fields []string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(f, "code") {
+			return true
+		}
+	}
+	return false
+}
+
+func columnIndex(fields []string) map[string]int {
+	index := make(map[string]int, len(fields))
+	for i, f := range fields {
+		index[strings.ToLower(f)] = i
+	}
+	return index
+}
+
+// parseShower разбирает строку; решения без орбиты (пустые q, e и т.д.) пропускаются
+func parseShower(fields []string, index map[string]int) (*Shower, error) {
+	get := func(name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return fields[i]
+	}
+	number := func(name string) (float64, bool) {
+		v, err := strconv.ParseFloat(get(name), 64)
+		return v, err == nil
+	}
+
+	shower := &Shower{
+		Code:       get("code"),
+		Name:       get("shower name"),
+		ParentBody: get("parent body"),
+	}
+	if shower.Code == "" {
+		return nil, fmt.Errorf("missing shower code")
+	}
+	shower.IAUNumber, _ = strconv.Atoi(get("iauno"))
+
+	orbital := []struct {
+		name   string
+		target *float64
+	}{
+		{"q", &shower.PerihelionDistance},
+		{"e", &shower.Eccentricity},
+		{"inc", &shower.InclinationDeg},
+		{"node", &shower.RaanDeg},
+		{"peri", &shower.ArgumentOfPerihelion},
+	}
+	for _, o := range orbital {
+		v, ok := number(o.name)
+		if !ok {
+			return nil, nil
+		}
+		*o.target = v
+	}
+
+	shower.SolarLongitude, _ = number("lasun")
+	shower.RadiantRA, _ = number("ra")
+	shower.RadiantDec, _ = number("de")
+	shower.GeocentricVelocity, _ = number("vg")
+
+	return shower, nil
+}
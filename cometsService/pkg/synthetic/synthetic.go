@@ -0,0 +1,91 @@
+// Package synthetic генерирует синтетические астрометрические наблюдения
+// по известной орбите для тестирования и проверки методов определения орбит.
+package synthetic
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/g0shi4ek/v0.1-cargo-comet-back/cometsService/pkg/orbit"
+)
+
+// MaxObservations ограничение на размер одной выборки
+const MaxObservations = 10000
+
+var (
+	ErrInvalidRange   = errors.New("end time must be after start time")
+	ErrInvalidCadence = errors.New("cadence must be positive")
+	ErrTooMany        = errors.New("too many observations requested")
+)
+
+// Config параметры генерации
+type Config struct {
+	Site        orbit.Site
+	Start       time.Time
+	End         time.Time
+	Cadence     time.Duration
+	NoiseArcsec float64 // СКО гауссова шума по каждой координате, угл. сек
+	Seed        int64
+	// VisibleOnly оставляет только моменты, когда объект выше MinAltitude, а Солнце ниже -12°
+	VisibleOnly bool
+	MinAltitude float64
+}
+
+// Observation синтетическое топоцентрическое наблюдение (J2000)
+type Observation struct {
+	Time time.Time
+	RA   float64 // градусы
+	Dec  float64 // градусы
+}
+
+// Generate вычисляет топоцентрические положения на сетке моментов и добавляет шум
+func Generate(el orbit.Elements, cfg Config) ([]Observation, error) {
+	if !cfg.End.After(cfg.Start) {
+		return nil, ErrInvalidRange
+	}
+	if cfg.Cadence <= 0 {
+		return nil, ErrInvalidCadence
+	}
+	if cfg.End.Sub(cfg.Start)/cfg.Cadence >= MaxObservations {
+		return nil, ErrTooMany
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	noise := cfg.NoiseArcsec / 3600
+
+	var observations []Observation
+	for t := cfg.Start; !t.After(cfg.End); t = t.Add(cfg.Cadence) {
+		jd, jdUT := orbit.JulianDate(t), orbit.JulianDateUT(t)
+		eph := el.TopocentricEphemerisAt(jd, cfg.Site.GeocentricPosition(jdUT))
+
+		if cfg.VisibleOnly && !visible(cfg, eph, jd, jdUT) {
+			continue
+		}
+
+		dec := eph.Dec + rng.NormFloat64()*noise
+		ra := eph.RA + rng.NormFloat64()*noise/math.Cos(eph.Dec*math.Pi/180)
+		ra = math.Mod(ra, 360)
+		if ra < 0 {
+			ra += 360
+		}
+
+		observations = append(observations, Observation{
+			Time: t.UTC(),
+			RA:   ra,
+			Dec:  math.Max(-90, math.Min(90, dec)),
+		})
+	}
+
+	return observations, nil
+}
+
+// visible проверяет, что объект над горизонтом, а на пункте астрономическая ночь
+func visible(cfg Config, eph orbit.Ephemeris, jd, jdUT float64) bool {
+	if cfg.Site.ObjectAltitude(eph.RA, eph.Dec, jdUT) < cfg.MinAltitude {
+		return false
+	}
+	sunRA, sunDec := orbit.SunRaDec(jd)
+	return cfg.Site.ObjectAltitude(sunRA, sunDec, jdUT) < -12
+}
@@ -0,0 +1,197 @@
+// Package timescale переводит моменты времени между шкалами UTC, TAI, TT и TDB
+// и в юлианские даты. Моменты представляются как time.Time (UTC), юлианские даты —
+// как число дней в явно указанной шкале.
+package timescale
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scale шкала времени
+type Scale string
+
+const (
+	UTC Scale = "UTC" // Всемирное координированное время
+	TAI Scale = "TAI" // Международное атомное время
+	TT  Scale = "TT"  // Земное время, TAI + 32.184 с
+	TDB Scale = "TDB" // Барицентрическое динамическое время
+)
+
+const (
+	// TTMinusTAI постоянная разность TT - TAI, секунды
+	TTMinusTAI = 32.184
+	// JDUnixEpoch юлианская дата начала эпохи Unix (1970-01-01T00:00:00 UTC)
+	JDUnixEpoch = 2440587.5
+
+	secondsPerDay = 86400
+)
+
+var (
+	ErrUnknownScale   = errors.New("unknown time scale")
+	ErrInvalidLeapRow = errors.New("invalid leap second table row")
+)
+
+// Встроенная таблица секунд координации IERS
+//
+//go:embed leapseconds.txt
+var bundled string
+
+// LeapSecond значение TAI-UTC, действующее с момента Since
+type LeapSecond struct {
+	Since       time.Time
+	TAIMinusUTC float64
+}
+
+// LeapSeconds таблица секунд координации, упорядоченная по времени
+type LeapSeconds []LeapSecond
+
+// Default встроенная таблица; ее можно заменить обновленной через Parse
+var Default = mustParse(bundled)
+
+// ParseScale разбирает название шкалы без учета регистра
+func ParseScale(s string) (Scale, error) {
+	switch scale := Scale(strings.ToUpper(strings.TrimSpace(s))); scale {
+	case UTC, TAI, TT, TDB:
+		return scale, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownScale, s)
+	}
+}
+
+// Parse читает таблицу в формате "ГГГГ-ММ-ДД TAI-UTC"; строки с # — комментарии
+func Parse(r io.Reader) (LeapSeconds, error) {
+	var table LeapSeconds
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w at line %d", ErrInvalidLeapRow, line)
+		}
+		since, err := time.Parse("2006-01-02", fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w at line %d: %v", ErrInvalidLeapRow, line, err)
+		}
+		offset, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w at line %d: %v", ErrInvalidLeapRow, line, err)
+		}
+		table = append(table, LeapSecond{Since: since, TAIMinusUTC: offset})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(table) == 0 {
+		return nil, fmt.Errorf("%w: table is empty", ErrInvalidLeapRow)
+	}
+
+	sort.Slice(table, func(i, j int) bool { return table[i].Since.Before(table[j].Since) })
+	return table, nil
+}
+
+func mustParse(s string) LeapSeconds {
+	table, err := Parse(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// TAIMinusUTC разность TAI-UTC в секундах на момент t. До 1972 года UTC
+// шел с переменной длиной секунды; для таких дат берется первое значение таблицы.
+func (l LeapSeconds) TAIMinusUTC(t time.Time) float64 {
+	i := sort.Search(len(l), func(i int) bool { return l[i].Since.After(t) })
+	if i == 0 {
+		return l[0].TAIMinusUTC
+	}
+	return l[i-1].TAIMinusUTC
+}
+
+// TAIMinusUTC разность TAI-UTC по встроенной таблице
+func TAIMinusUTC(t time.Time) float64 {
+	return Default.TAIMinusUTC(t)
+}
+
+// TDBMinusTT периодическая разность TDB - TT (секунды) для юлианской даты TT;
+// точность около 30 мкс
+func TDBMinusTT(jdTT float64) float64 {
+	g := (357.53 + 0.98560028*(jdTT-2451545.0)) * math.Pi / 180
+	return 0.001657*math.Sin(g) + 0.000014*math.Sin(2*g)
+}
+
+// Offset разность показаний шкалы и UTC в момент t, секунды
+func Offset(t time.Time, scale Scale) float64 {
+	switch scale {
+	case TAI:
+		return TAIMinusUTC(t)
+	case TT:
+		return TAIMinusUTC(t) + TTMinusTAI
+	case TDB:
+		tt := TAIMinusUTC(t) + TTMinusTAI
+		return tt + TDBMinusTT(unixJD(t)+tt/secondsPerDay)
+	default:
+		return 0
+	}
+}
+
+// JulianDate юлианская дата момента t в шкале scale
+func JulianDate(t time.Time, scale Scale) float64 {
+	return unixJD(t) + Offset(t, scale)/secondsPerDay
+}
+
+// FromJulianDate момент времени (UTC) по юлианской дате в шкале scale
+func FromJulianDate(jd float64, scale Scale) time.Time {
+	t := fromUnixJD(jd)
+	if scale == UTC || scale == "" {
+		return t
+	}
+
+	// Разность шкал зависит от самого момента: двух приближений хватает везде,
+	// кроме секунды координации
+	for range 2 {
+		t = fromUnixJD(jd - Offset(t, scale)/secondsPerDay)
+	}
+	return t
+}
+
+// Convert переводит юлианскую дату из одной шкалы в другую
+func Convert(jd float64, from, to Scale) float64 {
+	if from == to {
+		return jd
+	}
+	return JulianDate(FromJulianDate(jd, from), to)
+}
+
+func unixJD(t time.Time) float64 {
+	return JDUnixEpoch + float64(t.UnixNano())/float64(secondsPerDay*time.Second)
+}
+
+func fromUnixJD(jd float64) time.Time {
+	nanos := (jd - JDUnixEpoch) * float64(secondsPerDay*time.Second)
+	return time.Unix(0, int64(math.Round(nanos))).UTC()
+}
+
+// ToUTC переводит показание часов шкалы scale, записанное как time.Time
+// (например, дату из каталога в TT), в момент времени UTC
+func ToUTC(reading time.Time, scale Scale) time.Time {
+	// Без перевода через юлианскую дату, которая огрубляет момент до десятков микросекунд
+	if scale == UTC || scale == "" {
+		return reading.UTC()
+	}
+	return FromJulianDate(unixJD(reading), scale)
+}
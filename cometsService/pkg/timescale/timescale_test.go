@@ -0,0 +1,134 @@
+package timescale
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTAIMinusUTC(t *testing.T) {
+	tests := []struct {
+		at   string
+		want float64
+	}{
+		{"1965-06-01T00:00:00Z", 10},
+		{"1972-01-01T00:00:00Z", 10},
+		{"1972-06-30T23:59:59Z", 10},
+		{"1972-07-01T00:00:00Z", 11},
+		{"1999-01-01T00:00:00Z", 32},
+		{"2000-01-01T12:00:00Z", 32},
+		{"2012-06-30T23:59:59Z", 34},
+		{"2012-07-01T00:00:00Z", 35},
+		{"2016-12-31T23:59:59Z", 36},
+		{"2017-01-01T00:00:00Z", 37},
+		{"2025-10-18T00:00:00Z", 37},
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse(time.RFC3339, tt.at)
+		if got := TAIMinusUTC(at); got != tt.want {
+			t.Errorf("TAIMinusUTC(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestJulianDate(t *testing.T) {
+	// Эпоха J2000.0 — 2000-01-01 12:00 TT, то есть 11:58:55.816 UTC
+	j2000 := time.Date(2000, 1, 1, 11, 58, 55, 816000000, time.UTC)
+	tests := []struct {
+		scale Scale
+		want  float64
+	}{
+		{TT, 2451545.0},
+		{TAI, 2451545.0 - TTMinusTAI/secondsPerDay},
+		{UTC, 2451545.0 - (32+TTMinusTAI)/secondsPerDay},
+	}
+	for _, tt := range tests {
+		if got := JulianDate(j2000, tt.scale); math.Abs(got-tt.want)*secondsPerDay > 1e-4 {
+			t.Errorf("JulianDate(J2000, %s) = %.8f, want %.8f", tt.scale, got, tt.want)
+		}
+	}
+
+	tdb := JulianDate(j2000, TDB)
+	if diff := (tdb - 2451545.0) * secondsPerDay; math.Abs(diff) > 0.002 {
+		t.Errorf("TDB - TT at J2000 = %v s, want below 2 ms", diff)
+	}
+}
+
+// jdResolution разрешение юлианской даты в float64 около 2.45e6 суток — порядка 40 мкс
+const jdResolution = 50 * time.Microsecond
+
+func TestRoundTrip(t *testing.T) {
+	moments := []time.Time{
+		time.Date(1980, 3, 15, 6, 30, 0, 0, time.UTC),
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2017, 1, 1, 0, 0, 1, 0, time.UTC),
+		time.Date(2024, 7, 4, 18, 45, 12, 500000000, time.UTC),
+	}
+	for _, at := range moments {
+		for _, scale := range []Scale{UTC, TAI, TT, TDB} {
+			back := FromJulianDate(JulianDate(at, scale), scale)
+			if d := back.Sub(at); absDuration(d) > jdResolution {
+				t.Errorf("%s round trip of %s is off by %s", scale, at, d)
+			}
+		}
+
+		tt := JulianDate(at, TT)
+		tdb := Convert(tt, TT, TDB)
+		if diff := (tdb - tt) * secondsPerDay; math.Abs(diff) > 0.002 {
+			t.Errorf("TDB - TT at %s = %v s, want below 2 ms", at, diff)
+		}
+		if back := Convert(tdb, TDB, TT); math.Abs(back-tt)*secondsPerDay > 1e-5 {
+			t.Errorf("TT -> TDB -> TT at %s is off by %v s", at, (back-tt)*secondsPerDay)
+		}
+		if got := Convert(tt, TT, UTC); math.Abs(got-JulianDate(at, UTC))*secondsPerDay > 1e-5 {
+			t.Errorf("TT -> UTC at %s is off by %v s", at, (got-JulianDate(at, UTC))*secondsPerDay)
+		}
+	}
+}
+
+func TestToUTC(t *testing.T) {
+	reading := time.Date(2020, 5, 1, 0, 1, 9, 184000000, time.UTC)
+	want := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	if got := ToUTC(reading, TT); absDuration(got.Sub(want)) > jdResolution {
+		t.Errorf("ToUTC(%s, TT) = %s, want %s", reading, got, want)
+	}
+	if got := ToUTC(reading, UTC); !got.Equal(reading) {
+		t.Errorf("ToUTC(%s, UTC) = %s, want unchanged", reading, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	table, err := Parse(strings.NewReader("# comment\n2017-01-01 37\n\n1972-01-01 10\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table) != 2 || table[0].TAIMinusUTC != 10 || table[1].TAIMinusUTC != 37 {
+		t.Fatalf("Parse returned %+v, want two rows sorted by date", table)
+	}
+
+	for _, input := range []string{"", "# only comments\n", "2017-01-01\n", "2017-13-01 37\n", "2017-01-01 many\n"} {
+		if _, err := Parse(strings.NewReader(input)); !errors.Is(err, ErrInvalidLeapRow) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidLeapRow", input, err)
+		}
+	}
+}
+
+func TestParseScale(t *testing.T) {
+	for input, want := range map[string]Scale{"utc": UTC, " TT ": TT, "tdb": TDB, "Tai": TAI} {
+		if got, err := ParseScale(input); err != nil || got != want {
+			t.Errorf("ParseScale(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseScale("GPS"); !errors.Is(err, ErrUnknownScale) {
+		t.Errorf("ParseScale(GPS) error = %v, want ErrUnknownScale", err)
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}